@@ -2,12 +2,16 @@ package trading212
 
 import (
 	"errors"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"time"
 )
 
-const defaultTimeout = 5 * time.Second
+const (
+	defaultTimeout    = 5 * time.Second
+	defaultMaxRetries = 10
+)
 
 // APIURL string url for trading212 api.
 type APIURL string
@@ -33,21 +37,35 @@ type API struct {
 	apiSecret  SecureString
 	rateLimits *RateLimiter
 
-	client *http.Client
+	client      *http.Client
+	logger      *slog.Logger
+	userAgent   string
+	maxRetries  int
+	pageSize    int
+	retryPolicy RetryPolicy
+	retryHook   RetryHook
+
+	decodingMode DecodingMode
+	schemaDrifts *schemaDrifts
+
+	instrumentsCache *InstrumentsCache
+	enforcePreflight bool
+	validatePies     bool
 }
 
 // NewAPILive create a new client for trading212 API live.
-func NewAPILive(apiKey string, apiSecret SecureString) (*API, error) {
-	return NewAPI(apiURLLive, apiKey, apiSecret)
+func NewAPILive(apiKey string, apiSecret SecureString, opts ...Option) (*API, error) {
+	return NewAPI(apiURLLive, apiKey, apiSecret, opts...)
 }
 
 // NewAPIDemo create a new client for trading212 API demo.
-func NewAPIDemo(apiKey string, apiSecret SecureString) (*API, error) {
-	return NewAPI(apiURLDemo, apiKey, apiSecret)
+func NewAPIDemo(apiKey string, apiSecret SecureString, opts ...Option) (*API, error) {
+	return NewAPI(apiURLDemo, apiKey, apiSecret, opts...)
 }
 
 // NewAPI create a new client for trading212 API.
-func NewAPI(apiURL APIURL, apiKey string, apiSecret SecureString) (*API, error) {
+// Options are applied in order over the defaults, see Option.
+func NewAPI(apiURL APIURL, apiKey string, apiSecret SecureString, opts ...Option) (*API, error) {
 	if apiURL == "" {
 		return nil, errEmptyDomain
 	}
@@ -76,6 +94,19 @@ func NewAPI(apiURL APIURL, apiKey string, apiSecret SecureString) (*API, error)
 			Jar:           nil,
 			Timeout:       defaultTimeout,
 		},
+		logger:      slog.Default(),
+		userAgent:   "",
+		maxRetries:  defaultMaxRetries,
+		pageSize:    defaultPageSize,
+		retryPolicy: NewExponentialBackoff(),
+		retryHook:   nil,
+
+		decodingMode: DecodingLenient,
+		schemaDrifts: newSchemaDrifts(),
+
+		instrumentsCache: nil,
+		enforcePreflight: false,
+		validatePies:     false,
 		operations: &operations{
 			Account:          nil,
 			Instruments:      nil,
@@ -86,12 +117,39 @@ func NewAPI(apiURL APIURL, apiKey string, apiSecret SecureString) (*API, error)
 		},
 	}
 
+	rateLimits := api.rateLimits
+
+	for _, opt := range opts {
+		err := opt(api)
+		if err != nil {
+			return nil, errors.Join(errInvalidOption, err)
+		}
+	}
+
+	if api.rateLimits == rateLimits {
+		// only the private limiter follows the client logger
+		api.rateLimits.logger = api.logger
+	}
+
+	api.schemaDrifts.logger = api.logger
+
 	api.Account = &account{api}
 	api.Instruments = &instruments{api}
-	api.Orders = &orders{api}
+	if api.instrumentsCache != nil {
+		api.instrumentsCache.logger = api.logger
+		api.Instruments = &cachedInstruments{api: api, cache: api.instrumentsCache}
+	}
+
 	api.Positions = &positions{api}
 	api.HistoricalEvents = &historicalEvents{api}
-	api.Pies = &pies{api}
+
+	orders := &orders{
+		api: api, instruments: api.Instruments, enforcePreflight: api.enforcePreflight,
+		claims: newOrderClaims(), tracker: nil,
+	}
+	orders.tracker = newOrderTracker(orders, api.HistoricalEvents)
+	api.Orders = orders
+	api.Pies = &pies{api: api, instruments: api.Instruments, validate: api.validatePies}
 
 	return api, nil
 }
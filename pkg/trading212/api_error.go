@@ -0,0 +1,144 @@
+package trading212
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize read from a non-2xx response.
+const maxErrorBodySize = 64 << 10
+
+// APIError is returned for every non-2xx API response.
+// It wraps one of the sentinel errors, so both errors.As and the Is* helpers can be used.
+type APIError struct {
+	// StatusCode of the http response.
+	StatusCode int
+	// Status of the http response, e.g. "400 Bad Request".
+	Status string
+	// Method of the http request.
+	Method string
+	// Endpoint template of the http request, see APIEndpoint.Template.
+	Endpoint APIEndpoint
+	// Code of the error given by Trading212, if any.
+	Code string
+	// Message of the error given by Trading212, if any.
+	Message string
+	// Body of the http response, truncated to 64KiB.
+	Body []byte
+	// RateLimits of the endpoint after the call, nil when unknown.
+	RateLimits *APIRateLimits
+
+	err error
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("%v (status: %s)", e.err, e.Status)
+
+	if e.Method != "" {
+		message += fmt.Sprintf(" on %s %s", e.Method, e.Endpoint)
+	}
+
+	switch {
+	case e.Code != "" && e.Message != "":
+		message += fmt.Sprintf(": %s: %s", e.Code, e.Message)
+	case e.Code != "" || e.Message != "":
+		message += ": " + e.Code + e.Message
+	}
+
+	return message
+}
+
+// Unwrap returns the sentinel error matching the status code.
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+// errorBody are the fields Trading212 uses to describe an error.
+type errorBody struct {
+	Code          string `json:"code"`
+	ErrorCode     string `json:"errorCode"`
+	Type          string `json:"type"`
+	Message       string `json:"message"`
+	ErrorMessage  string `json:"errorMessage"`
+	Clarification string `json:"clarification"`
+	Detail        string `json:"detail"`
+	Title         string `json:"title"`
+}
+
+// parseBody fills Code and Message from the response body, when it is json.
+func (e *APIError) parseBody(body []byte) {
+	e.Body = body
+
+	var parsed errorBody
+
+	err := json.Unmarshal(body, &parsed)
+	if err != nil {
+		e.Message = strings.TrimSpace(string(body))
+
+		return
+	}
+
+	e.Code = firstNonEmpty(parsed.Code, parsed.ErrorCode, parsed.Type)
+	e.Message = firstNonEmpty(parsed.Message, parsed.ErrorMessage, parsed.Clarification, parsed.Detail, parsed.Title)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// IsAuth reports whether the error is due to a bad API key.
+func IsAuth(err error) bool {
+	return errors.Is(err, errHTTP401)
+}
+
+// IsScopeMissing reports whether the API key lacks the scope required by the endpoint.
+func IsScopeMissing(err error) bool {
+	return errors.Is(err, errHTTP403)
+}
+
+// IsNotFound reports whether the requested entity does not exist, e.g. an order no longer pending.
+func IsNotFound(err error) bool {
+	var apiError *APIError
+
+	return errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound
+}
+
+// IsRateLimited reports whether the request was rejected by the rate-limits.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, errHTTP429)
+}
+
+// IsRetryable reports whether the failed request can be safely sent again:
+// timeouts, rate-limits, server and transport errors.
+// Order placements whose outcome is unknown are not, see OrderOutcomeUnknownError.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, errOutcomeUnknown) {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, errHTTP408) || errors.Is(err, errHTTP429) {
+		return true
+	}
+
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		return apiError.StatusCode >= http.StatusInternalServerError
+	}
+
+	return errors.Is(err, errAPIRequest)
+}
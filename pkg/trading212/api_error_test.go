@@ -0,0 +1,175 @@
+package trading212
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_APIError_Response(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantCode    string
+		wantMessage string
+		wantErr     error
+	}{
+		{
+			name:        "json error body",
+			status:      http.StatusNotFound,
+			body:        `{"code": "OrderNotFound", "message": "order does not exist"}`,
+			wantCode:    "OrderNotFound",
+			wantMessage: "order does not exist",
+			wantErr:     errNon200,
+		},
+		{
+			name:        "alternative field names",
+			status:      http.StatusBadRequest,
+			body:        `{"type": "/api-errors/invalid", "clarification": "quantity too small"}`,
+			wantCode:    "/api-errors/invalid",
+			wantMessage: "quantity too small",
+			wantErr:     errNon200,
+		},
+		{
+			name:        "plain text body",
+			status:      http.StatusForbidden,
+			body:        "scope missing\n",
+			wantCode:    "",
+			wantMessage: "scope missing",
+			wantErr:     errHTTP403,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockAPI, terminate, err := newMockAPI(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set(RateLimitHeaderLimit, "50")
+						w.Header().Set(RateLimitHeaderPeriod, "60")
+						w.Header().Set(RateLimitHeaderRemaining, "49")
+						w.Header().Set(RateLimitHeaderUsed, "1")
+						w.Header().Set(RateLimitHeaderReset, fmt.Sprint(time.Now().Add(time.Minute).Unix()))
+						w.WriteHeader(tt.status)
+						_, _ = fmt.Fprint(w, tt.body)
+					},
+				),
+			)
+			defer terminate()
+
+			if err != nil {
+				t.Fatalf("Error creating mock api; %v", err)
+			}
+
+			err = mockAPI.Orders.CancelOrderWithContext(context.Background(), 42)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CancelOrder() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var apiError *APIError
+			if !errors.As(err, &apiError) {
+				t.Fatalf("CancelOrder() error = %v, want *APIError", err)
+			}
+
+			if apiError.StatusCode != tt.status || apiError.Method != http.MethodDelete ||
+				apiError.Endpoint != CancelOrder+"/{id}" {
+				t.Errorf("APIError = %+v, want status %d on DELETE %s/{id}", apiError, tt.status, CancelOrder)
+			}
+
+			if apiError.Code != tt.wantCode || apiError.Message != tt.wantMessage {
+				t.Errorf("APIError code, message = %q, %q, want %q, %q",
+					apiError.Code, apiError.Message, tt.wantCode, tt.wantMessage)
+			}
+
+			if string(apiError.Body) != tt.body {
+				t.Errorf("APIError body = %q, want %q", apiError.Body, tt.body)
+			}
+
+			if apiError.RateLimits == nil || apiError.RateLimits.Limit != 50 {
+				t.Errorf("APIError rate limits = %+v, want limit 50", apiError.RateLimits)
+			}
+		})
+	}
+}
+
+func Test_APIError_Error(t *testing.T) {
+	t.Parallel()
+
+	apiError := &APIError{
+		StatusCode: http.StatusNotFound,
+		Status:     "404 Not Found",
+		Method:     http.MethodGet,
+		Endpoint:   GetPendingOrderByID + "/{id}",
+		Code:       "OrderNotFound",
+		Message:    "order does not exist",
+		Body:       nil,
+		RateLimits: nil,
+		err:        errNon200,
+	}
+
+	want := "error api return non http 200 (status: 404 Not Found) on GET /api/v0/equity/orders/{id}: " +
+		"OrderNotFound: order does not exist"
+	if got := apiError.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func Test_APIError_helpers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		err             error
+		wantRetryable   bool
+		wantAuth        bool
+		wantScope       bool
+		wantRateLimited bool
+		wantNotFound    bool
+	}{
+		{name: "nil", err: nil},
+		{name: "401", err: httpError(http.StatusUnauthorized, "401"), wantAuth: true},
+		{name: "403", err: httpError(http.StatusForbidden, "403"), wantScope: true},
+		{name: "404", err: httpError(http.StatusNotFound, "404"), wantNotFound: true},
+		{name: "408", err: httpError(http.StatusRequestTimeout, "408"), wantRetryable: true},
+		{name: "429", err: httpError(http.StatusTooManyRequests, "429"), wantRetryable: true, wantRateLimited: true},
+		{name: "503", err: httpError(http.StatusServiceUnavailable, "503"), wantRetryable: true},
+		{name: "transport", err: errors.Join(errAPIRequest, errors.New("connection reset")), wantRetryable: true},
+		{
+			name: "outcome unknown",
+			err:  errors.Join(errOutcomeUnknown, httpError(http.StatusServiceUnavailable, "503")),
+		},
+		{name: "cancelled", err: errors.Join(errAPIRequest, context.Canceled)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsRetryable(tt.err); got != tt.wantRetryable {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.wantRetryable)
+			}
+
+			if got := IsAuth(tt.err); got != tt.wantAuth {
+				t.Errorf("IsAuth() = %v, want %v", got, tt.wantAuth)
+			}
+
+			if got := IsScopeMissing(tt.err); got != tt.wantScope {
+				t.Errorf("IsScopeMissing() = %v, want %v", got, tt.wantScope)
+			}
+
+			if got := IsRateLimited(tt.err); got != tt.wantRateLimited {
+				t.Errorf("IsRateLimited() = %v, want %v", got, tt.wantRateLimited)
+			}
+
+			if got := IsNotFound(tt.err); got != tt.wantNotFound {
+				t.Errorf("IsNotFound() = %v, want %v", got, tt.wantNotFound)
+			}
+		})
+	}
+}
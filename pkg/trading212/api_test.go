@@ -39,6 +39,14 @@ func validateAPI(api *API, err error) error {
 		return errors.New("http client is nil")
 	}
 
+	if api.logger == nil {
+		return errors.New("logger is nil")
+	}
+
+	if api.retryPolicy == nil {
+		return errors.New("retryPolicy is nil")
+	}
+
 	// check embedded operations
 	if api.operations == nil {
 		return errors.New("operations is nil")
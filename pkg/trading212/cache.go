@@ -0,0 +1,158 @@
+package trading212
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+var (
+	errCacheStore = errors.New("error accessing cache store")
+	errCacheKey   = errors.New("invalid cache key")
+)
+
+// cacheKeyPattern restricts the keys, so they are safe file names.
+var cacheKeyPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// CacheStore persists the cached responses, as json, with the time they were fetched.
+// Implementations must be safe for concurrent use.
+type CacheStore interface {
+	// Load returns the data stored under key, or nil data when there is none.
+	Load(key string) (data []byte, storedAt time.Time, err error)
+	// Store saves the data under key, replacing any previous one.
+	Store(key string, data []byte, storedAt time.Time) error
+}
+
+type cacheRecord struct {
+	StoredAt time.Time       `json:"storedAt"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// MemoryCacheStore keeps the cached responses in memory, for the lifetime of the process.
+type MemoryCacheStore struct {
+	mutex   sync.RWMutex
+	records map[string]cacheRecord
+}
+
+// NewMemoryCacheStore create an empty MemoryCacheStore.
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{
+		mutex:   sync.RWMutex{},
+		records: map[string]cacheRecord{},
+	}
+}
+
+// Load implements CacheStore.
+func (store *MemoryCacheStore) Load(key string) ([]byte, time.Time, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	record := store.records[key]
+
+	return record.Data, record.StoredAt, nil
+}
+
+// Store implements CacheStore.
+func (store *MemoryCacheStore) Store(key string, data []byte, storedAt time.Time) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.records[key] = cacheRecord{StoredAt: storedAt, Data: data}
+
+	return nil
+}
+
+// FileCacheStore keeps the cached responses in a directory, one json file per key,
+// so a restarted process does not need the network to warm up.
+type FileCacheStore struct {
+	mutex sync.Mutex
+	dir   string
+}
+
+// NewFileCacheStore create a FileCacheStore in dir, creating it if needed.
+func NewFileCacheStore(dir string) (*FileCacheStore, error) {
+	err := os.MkdirAll(dir, 0o700) //nolint:mnd
+	if err != nil {
+		return nil, errors.Join(errCacheStore, err)
+	}
+
+	return &FileCacheStore{mutex: sync.Mutex{}, dir: dir}, nil
+}
+
+// Load implements CacheStore.
+func (store *FileCacheStore) Load(key string) ([]byte, time.Time, error) {
+	path, err := store.path(key)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	store.mutex.Lock()
+	content, err := os.ReadFile(path) //nolint:gosec // key is validated
+	store.mutex.Unlock()
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, time.Time{}, nil
+	}
+
+	if err != nil {
+		return nil, time.Time{}, errors.Join(errCacheStore, err)
+	}
+
+	var record cacheRecord
+
+	err = json.Unmarshal(content, &record)
+	if err != nil {
+		return nil, time.Time{}, errors.Join(errCacheStore, err)
+	}
+
+	return record.Data, record.StoredAt, nil
+}
+
+// Store implements CacheStore.
+// The file is replaced atomically, so a crash never leaves a partial record.
+func (store *FileCacheStore) Store(key string, data []byte, storedAt time.Time) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(cacheRecord{StoredAt: storedAt, Data: data})
+	if err != nil {
+		return errors.Join(errCacheStore, err)
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	temporary, err := os.CreateTemp(store.dir, key+".*.tmp")
+	if err != nil {
+		return errors.Join(errCacheStore, err)
+	}
+
+	_, err = temporary.Write(content)
+	err = errors.Join(err, temporary.Close())
+
+	if err == nil {
+		err = os.Rename(temporary.Name(), path)
+	}
+
+	if err != nil {
+		_ = os.Remove(temporary.Name())
+
+		return errors.Join(errCacheStore, err)
+	}
+
+	return nil
+}
+
+func (store *FileCacheStore) path(key string) (string, error) {
+	if !cacheKeyPattern.MatchString(key) {
+		return "", errors.Join(errCacheStore, errCacheKey)
+	}
+
+	return filepath.Join(store.dir, key+".json"), nil
+}
@@ -0,0 +1,63 @@
+package trading212
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_CacheStore(t *testing.T) {
+	t.Parallel()
+
+	fileStore, err := NewFileCacheStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCacheStore() error = %v", err)
+	}
+
+	stores := map[string]CacheStore{
+		"MemoryCacheStore": NewMemoryCacheStore(),
+		"FileCacheStore":   fileStore,
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, storedAt, err := store.Load("missing")
+			if err != nil || data != nil || !storedAt.IsZero() {
+				t.Errorf("Load() = %s, %v, %v, want nothing", data, storedAt, err)
+			}
+
+			now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+			for _, value := range []string{`[1]`, `[1,2]`} {
+				err = store.Store("key", []byte(value), now)
+				if err != nil {
+					t.Fatalf("Store() error = %v", err)
+				}
+
+				data, storedAt, err = store.Load("key")
+				if err != nil || string(data) != value || !storedAt.Equal(now) {
+					t.Errorf("Load() = %s, %v, %v, want %s, %v", data, storedAt, err, value, now)
+				}
+			}
+		})
+	}
+}
+
+func Test_FileCacheStore_InvalidKey(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewFileCacheStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCacheStore() error = %v", err)
+	}
+
+	err = store.Store("../escape", []byte(`[]`), time.Now())
+	if !errors.Is(err, errCacheKey) {
+		t.Errorf("Store() error = %v, want %v", err, errCacheKey)
+	}
+
+	_, _, err = store.Load("../escape")
+	if !errors.Is(err, errCacheKey) {
+		t.Errorf("Load() error = %v, want %v", err, errCacheKey)
+	}
+}
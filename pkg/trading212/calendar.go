@@ -0,0 +1,229 @@
+package trading212
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+var (
+	errUnknownTicker   = errors.New("unknown ticker")
+	errUnknownSchedule = errors.New("unknown working schedule")
+	errOutsideSchedule = errors.New("time outside of the working schedule")
+	errNoNextEvent     = errors.New("no such event in the working schedule")
+)
+
+// Session of the market at a given time.
+type Session int
+
+const (
+	// SessionClosed the market is closed.
+	SessionClosed Session = iota
+	// SessionPreMarket trading before the regular hours.
+	SessionPreMarket
+	// SessionRegular trading during the regular hours.
+	SessionRegular
+	// SessionBreak the regular hours are paused.
+	SessionBreak
+	// SessionAfterHours trading after the regular hours.
+	SessionAfterHours
+	// SessionOvernight trading overnight.
+	SessionOvernight
+)
+
+// String implements fmt.Stringer.
+func (session Session) String() string {
+	switch session {
+	case SessionClosed:
+		return "CLOSED"
+	case SessionPreMarket:
+		return "PRE_MARKET"
+	case SessionRegular:
+		return "REGULAR"
+	case SessionBreak:
+		return "BREAK"
+	case SessionAfterHours:
+		return "AFTER_HOURS"
+	case SessionOvernight:
+		return "OVERNIGHT"
+	default:
+		return fmt.Sprintf("Session(%d)", int(session))
+	}
+}
+
+// sessionAfter maps the time events to the session they start.
+var sessionAfter = map[models.TimeEventType]Session{
+	models.TimeEventOpen:            SessionRegular,
+	models.TimeEventClose:           SessionClosed,
+	models.TimeEventBreakStart:      SessionBreak,
+	models.TimeEventBreakEnd:        SessionRegular,
+	models.TimeEventPreMarketOpen:   SessionPreMarket,
+	models.TimeEventAfterHoursOpen:  SessionAfterHours,
+	models.TimeEventAfterHoursClose: SessionClosed,
+	models.TimeEventOvernightOpen:   SessionOvernight,
+}
+
+type calendarEvent struct {
+	date      time.Time
+	eventType models.TimeEventType
+}
+
+// Calendar interprets the working schedules of the exchanges, as given by GetExchangesMetadata.
+// The schedules only cover a limited window around the current date, times outside it are errors.
+// It is immutable, so safe for concurrent use.
+type Calendar struct {
+	catalog   *Catalog
+	schedules map[uint][]calendarEvent
+}
+
+// NewCalendar builds a Calendar from the working schedules of the catalog.
+func NewCalendar(catalog *Catalog) *Calendar {
+	calendar := &Calendar{catalog: catalog, schedules: map[uint][]calendarEvent{}}
+
+	for _, exchange := range catalog.bySchedule {
+		for _, schedule := range exchange.WorkingSchedules {
+			if _, found := calendar.schedules[schedule.ID]; found {
+				continue
+			}
+
+			events := make([]calendarEvent, 0, len(schedule.TimeEvents))
+			for _, event := range schedule.TimeEvents {
+				events = append(events, calendarEvent{date: event.Date, eventType: event.Type})
+			}
+
+			// at the same time, a session ends before the next one starts
+			slices.SortStableFunc(events, func(a, b calendarEvent) int {
+				return cmp.Or(a.date.Compare(b.date), cmp.Compare(eventRank(a), eventRank(b)))
+			})
+
+			calendar.schedules[schedule.ID] = events
+		}
+	}
+
+	return calendar
+}
+
+func eventRank(event calendarEvent) int {
+	if sessionAfter[event.eventType] == SessionClosed {
+		return 0
+	}
+
+	return 1
+}
+
+// events returns the sorted time events of the working schedule of the ticker.
+func (calendar *Calendar) events(ticker models.Ticker) ([]calendarEvent, error) {
+	instrument, found := calendar.catalog.Instrument(ticker)
+	if !found {
+		return nil, fmt.Errorf("%w: %s", errUnknownTicker, ticker)
+	}
+
+	events, found := calendar.schedules[instrument.WorkingScheduleID]
+	if !found || len(events) == 0 {
+		return nil, fmt.Errorf("%w: %d of %s", errUnknownSchedule, instrument.WorkingScheduleID, ticker)
+	}
+
+	return events, nil
+}
+
+// SessionAt returns the session of the market of the ticker at t.
+func (calendar *Calendar) SessionAt(ticker models.Ticker, at time.Time) (Session, error) {
+	events, err := calendar.events(ticker)
+	if err != nil {
+		return SessionClosed, err
+	}
+
+	// index of the first event after at
+	index, _ := slices.BinarySearchFunc(events, at, func(event calendarEvent, target time.Time) int {
+		if event.date.After(target) {
+			return 1
+		}
+
+		return -1
+	})
+	if index == 0 || index == len(events) {
+		return SessionClosed, fmt.Errorf("%w: %s", errOutsideSchedule, at)
+	}
+
+	return sessionAfter[events[index-1].eventType], nil
+}
+
+// IsOpen reports whether the market of the ticker is in its regular hours at t.
+// Orders sent outside them are queued until the next open, unless placed for extended hours.
+func (calendar *Calendar) IsOpen(ticker models.Ticker, at time.Time) (bool, error) {
+	session, err := calendar.SessionAt(ticker, at)
+
+	return session == SessionRegular, err
+}
+
+// NextOpen returns the start of the next regular hours of the market of the ticker, after t.
+func (calendar *Calendar) NextOpen(ticker models.Ticker, after time.Time) (time.Time, error) {
+	return calendar.next(ticker, after, models.TimeEventOpen)
+}
+
+// NextClose returns the end of the next regular hours of the market of the ticker, after t.
+func (calendar *Calendar) NextClose(ticker models.Ticker, after time.Time) (time.Time, error) {
+	return calendar.next(ticker, after, models.TimeEventClose)
+}
+
+func (calendar *Calendar) next(ticker models.Ticker, after time.Time, eventType models.TimeEventType) (time.Time, error) {
+	events, err := calendar.events(ticker)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for _, event := range events {
+		if event.date.After(after) && event.eventType == eventType {
+			return event.date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %s after %s", errNoNextEvent, eventType, after)
+}
+
+// Holidays returns the upcoming weekdays, from the date of t, without regular hours in the
+// working schedule of the ticker, as midnight UTC. They are inferred from the schedule, so
+// only cover the days fully inside its window.
+func (calendar *Calendar) Holidays(ticker models.Ticker, from time.Time) ([]time.Time, error) {
+	events, err := calendar.events(ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	openDays := map[time.Time]bool{}
+	for _, event := range events {
+		if event.eventType == models.TimeEventOpen {
+			openDays[truncateDay(event.date)] = true
+		}
+	}
+
+	var holidays []time.Time
+
+	// the first and last days may be partially covered
+	start := truncateDay(events[0].date).AddDate(0, 0, 1)
+	if day := truncateDay(from); day.After(start) {
+		start = day
+	}
+
+	end := truncateDay(events[len(events)-1].date)
+
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		weekday := day.Weekday()
+		if weekday != time.Saturday && weekday != time.Sunday && !openDays[day] {
+			holidays = append(holidays, day)
+		}
+	}
+
+	return holidays, nil
+}
+
+// truncateDay returns midnight UTC of the day of t.
+func truncateDay(at time.Time) time.Time {
+	year, month, day := at.UTC().Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,167 @@
+package trading212
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+// calendarSchedule covers Friday 2023-12-29 to Wednesday 2024-01-03, closed on Monday 2024-01-01.
+var calendarSchedule = []string{
+	"2023-12-29T09:00:00Z PRE_MARKET_OPEN",
+	"2023-12-29T14:30:00Z OPEN",
+	"2023-12-29T16:00:00Z BREAK_START",
+	"2023-12-29T16:30:00Z BREAK_END",
+	"2023-12-29T21:00:00Z CLOSE",
+	"2023-12-29T21:00:00Z AFTER_HOURS_OPEN",
+	"2023-12-29T23:00:00Z AFTER_HOURS_CLOSE",
+	"2024-01-02T14:30:00Z OPEN",
+	"2024-01-02T21:00:00Z CLOSE",
+	"2024-01-02T22:00:00Z OVERNIGHT_OPEN",
+	"2024-01-03T14:30:00Z OPEN",
+	"2024-01-03T21:00:00Z CLOSE",
+}
+
+func newTestCalendar(t *testing.T) *Calendar {
+	t.Helper()
+
+	events := make([]string, 0, len(calendarSchedule))
+	for _, event := range slices.Backward(calendarSchedule) {
+		date, eventType, _ := strings.Cut(event, " ")
+		events = append(events, fmt.Sprintf(`{"date": %q, "type": %q}`, date, eventType))
+	}
+
+	var exchange *models.ExchangeMetadata
+
+	err := json.Unmarshal([]byte(fmt.Sprintf(
+		`{"id": 1, "name": "NYSE", "workingSchedules": [{"id": 7, "timeEvents": [%s]}]}`, strings.Join(events, ","),
+	)), &exchange)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	instruments := []*models.Instrument{
+		{Ticker: "AAPL_US_EQ", WorkingScheduleID: 7},
+		{Ticker: "NOPE_US_EQ", WorkingScheduleID: 8},
+	}
+
+	return NewCalendar(NewCatalog(slices.Values(instruments), slices.Values([]*models.ExchangeMetadata{exchange})))
+}
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	return parsed
+}
+
+func Test_Calendar_SessionAt(t *testing.T) {
+	t.Parallel()
+
+	calendar := newTestCalendar(t)
+
+	tests := []struct {
+		at       string
+		ticker   models.Ticker
+		want     Session
+		wantOpen bool
+		wantErr  error
+	}{
+		{at: "2023-12-29T10:00:00Z", want: SessionPreMarket},
+		{at: "2023-12-29T14:30:00Z", want: SessionRegular, wantOpen: true},
+		{at: "2023-12-29T16:15:00Z", want: SessionBreak},
+		{at: "2023-12-29T17:00:00Z", want: SessionRegular, wantOpen: true},
+		{at: "2023-12-29T22:00:00Z", want: SessionAfterHours},
+		{at: "2024-01-01T15:00:00Z", want: SessionClosed},
+		{at: "2024-01-02T23:00:00Z", want: SessionOvernight},
+		{at: "2023-12-28T15:00:00Z", want: SessionClosed, wantErr: errOutsideSchedule},
+		{at: "2024-01-04T15:00:00Z", want: SessionClosed, wantErr: errOutsideSchedule},
+		{at: "2024-01-02T15:00:00Z", ticker: "NOPE_US_EQ", want: SessionClosed, wantErr: errUnknownSchedule},
+		{at: "2024-01-02T15:00:00Z", ticker: "MISSING", want: SessionClosed, wantErr: errUnknownTicker},
+	}
+	for _, tt := range tests {
+		ticker := cmp.Or(tt.ticker, "AAPL_US_EQ")
+		t.Run(ticker.String()+" "+tt.at, func(t *testing.T) {
+			t.Parallel()
+
+			at := mustParseTime(t, tt.at)
+
+			session, err := calendar.SessionAt(ticker, at)
+			if session != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("SessionAt() = %v, %v, want %v, %v", session, err, tt.want, tt.wantErr)
+			}
+
+			open, err := calendar.IsOpen(ticker, at)
+			if open != tt.wantOpen || !errors.Is(err, tt.wantErr) {
+				t.Errorf("IsOpen() = %v, %v, want %v, %v", open, err, tt.wantOpen, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_Calendar_Next(t *testing.T) {
+	t.Parallel()
+
+	calendar := newTestCalendar(t)
+	after := mustParseTime(t, "2023-12-29T22:00:00Z")
+
+	open, err := calendar.NextOpen("AAPL_US_EQ", after)
+	if err != nil || !open.Equal(mustParseTime(t, "2024-01-02T14:30:00Z")) {
+		t.Errorf("NextOpen() = %v, %v, want Tuesday open", open, err)
+	}
+
+	closing, err := calendar.NextClose("AAPL_US_EQ", open)
+	if err != nil || !closing.Equal(mustParseTime(t, "2024-01-02T21:00:00Z")) {
+		t.Errorf("NextClose() = %v, %v, want Tuesday close", closing, err)
+	}
+
+	_, err = calendar.NextOpen("AAPL_US_EQ", mustParseTime(t, "2024-01-03T15:00:00Z"))
+	if !errors.Is(err, errNoNextEvent) {
+		t.Errorf("NextOpen() error = %v, want %v", err, errNoNextEvent)
+	}
+}
+
+func Test_Calendar_Holidays(t *testing.T) {
+	t.Parallel()
+
+	calendar := newTestCalendar(t)
+
+	tests := []struct {
+		from string
+		want string
+	}{
+		{from: "2023-12-01T00:00:00Z", want: "[2024-01-01]"},
+		{from: "2024-01-01T12:00:00Z", want: "[2024-01-01]"},
+		{from: "2024-01-02T00:00:00Z", want: "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from, func(t *testing.T) {
+			t.Parallel()
+
+			holidays, err := calendar.Holidays("AAPL_US_EQ", mustParseTime(t, tt.from))
+			if err != nil {
+				t.Fatalf("Holidays() error = %v", err)
+			}
+
+			days := make([]string, 0, len(holidays))
+			for _, holiday := range holidays {
+				days = append(days, holiday.Format(time.DateOnly))
+			}
+
+			if fmt.Sprint(days) != tt.want {
+				t.Errorf("Holidays() = %v, want %v", days, tt.want)
+			}
+		})
+	}
+}
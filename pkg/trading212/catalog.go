@@ -0,0 +1,295 @@
+package trading212
+
+import (
+	"cmp"
+	"context"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+// Catalog indexes the instruments and exchanges for lookups and searches.
+// It is immutable, so safe for concurrent use; build a new one to refresh it.
+type Catalog struct {
+	instruments []*models.Instrument
+	byTicker    map[models.Ticker]*models.Instrument
+	byISIN      map[string][]*models.Instrument
+	// bySymbol and byFoldedTicker are keyed in upper case, for the user inputs, see ResolveTicker.
+	bySymbol       map[string][]*models.Instrument
+	byFoldedTicker map[string][]*models.Instrument
+	bySchedule     map[uint]*models.ExchangeMetadata
+	// names are the lowercase Name and ShortName, sorted for prefix searches.
+	names []catalogName
+}
+
+type catalogName struct {
+	name       string
+	instrument *models.Instrument
+}
+
+// InstrumentFilter selects instruments, zero values match everything.
+type InstrumentFilter struct {
+	// Type, e.g. models.InstrumentTypeStock, matched case-insensitively.
+	Type models.InstrumentType
+	// CurrencyCode, in ISO 4217, e.g. "USD".
+	CurrencyCode string
+	// ExtendedHours, whether the instrument trades outside the regular hours.
+	ExtendedHours *bool
+}
+
+func (filter InstrumentFilter) matches(instrument *models.Instrument) bool {
+	return (filter.Type == "" || strings.EqualFold(string(filter.Type), string(instrument.Type))) &&
+		(filter.CurrencyCode == "" || strings.EqualFold(filter.CurrencyCode, instrument.CurrencyCode)) &&
+		(filter.ExtendedHours == nil || *filter.ExtendedHours == instrument.ExtendedHours)
+}
+
+// NewCatalog builds a Catalog from the results of GetAllAvailableInstruments and GetExchangesMetadata.
+func NewCatalog(
+	instruments iter.Seq[*models.Instrument], exchanges iter.Seq[*models.ExchangeMetadata],
+) *Catalog {
+	catalog := &Catalog{
+		instruments:    nil,
+		byTicker:       map[models.Ticker]*models.Instrument{},
+		byISIN:         map[string][]*models.Instrument{},
+		bySymbol:       map[string][]*models.Instrument{},
+		byFoldedTicker: map[string][]*models.Instrument{},
+		bySchedule:     map[uint]*models.ExchangeMetadata{},
+		names:          nil,
+	}
+
+	for instrument := range instruments {
+		if instrument == nil {
+			continue
+		}
+
+		catalog.instruments = append(catalog.instruments, instrument)
+		catalog.byTicker[instrument.Ticker] = instrument
+		catalog.index(instrument)
+
+		if instrument.Isin != "" {
+			isin := strings.ToUpper(instrument.Isin)
+			catalog.byISIN[isin] = append(catalog.byISIN[isin], instrument)
+		}
+
+		for _, name := range []string{instrument.Name, instrument.ShortName} {
+			if name != "" {
+				catalog.names = append(catalog.names, catalogName{name: strings.ToLower(name), instrument: instrument})
+			}
+		}
+	}
+
+	slices.SortFunc(catalog.names, func(a, b catalogName) int {
+		return cmp.Or(strings.Compare(a.name, b.name), strings.Compare(string(a.instrument.Ticker), string(b.instrument.Ticker)))
+	})
+
+	for exchange := range exchanges {
+		if exchange == nil {
+			continue
+		}
+
+		for _, schedule := range exchange.WorkingSchedules {
+			catalog.bySchedule[schedule.ID] = exchange
+		}
+	}
+
+	return catalog
+}
+
+// LoadCatalog fetches the instruments and exchanges, then builds a Catalog.
+// Combine it with WithInstrumentsCache to avoid fetching them every time.
+func LoadCatalog(ctx context.Context, api *API) (*Catalog, error) {
+	instruments, err := api.Instruments.GetAllAvailableInstrumentsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exchanges, err := api.Instruments.GetExchangesMetadataWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCatalog(instruments, exchanges), nil
+}
+
+// Len returns the number of instruments.
+func (catalog *Catalog) Len() int {
+	return len(catalog.instruments)
+}
+
+// All returns every instrument, in the order of the API.
+func (catalog *Catalog) All() iter.Seq[*models.Instrument] {
+	return slices.Values(catalog.instruments)
+}
+
+// Instrument returns the instrument of the ticker, e.g. "AAPL_US_EQ".
+func (catalog *Catalog) Instrument(ticker models.Ticker) (*models.Instrument, bool) {
+	instrument, found := catalog.byTicker[ticker]
+
+	return instrument, found
+}
+
+// ByISIN returns the instruments of the ISIN, one per listing.
+func (catalog *Catalog) ByISIN(isin string) []*models.Instrument {
+	return slices.Clone(catalog.byISIN[strings.ToUpper(isin)])
+}
+
+// Exchange returns the exchange of the instrument, through its working schedule.
+func (catalog *Catalog) Exchange(instrument *models.Instrument) (*models.ExchangeMetadata, bool) {
+	exchange, found := catalog.bySchedule[instrument.WorkingScheduleID]
+
+	return exchange, found
+}
+
+// Filter returns the instruments matching the filter.
+func (catalog *Catalog) Filter(filter InstrumentFilter) iter.Seq[*models.Instrument] {
+	return func(yield func(*models.Instrument) bool) {
+		for _, instrument := range catalog.instruments {
+			if filter.matches(instrument) && !yield(instrument) {
+				return
+			}
+		}
+	}
+}
+
+// SearchPrefix returns the instruments whose Name or ShortName starts with prefix, ignoring case,
+// sorted by name.
+func (catalog *Catalog) SearchPrefix(prefix string) []*models.Instrument {
+	prefix = strings.ToLower(prefix)
+
+	start, _ := slices.BinarySearchFunc(catalog.names, prefix, func(entry catalogName, target string) int {
+		return strings.Compare(entry.name, target)
+	})
+
+	var results []*models.Instrument
+
+	for _, entry := range catalog.names[start:] {
+		if !strings.HasPrefix(entry.name, prefix) {
+			break
+		}
+
+		if !slices.Contains(results, entry.instrument) {
+			results = append(results, entry.instrument)
+		}
+	}
+
+	return results
+}
+
+// Search returns at most limit instruments whose Name or ShortName approximately match the query,
+// best matches first: exact, prefix, word prefix, substring, then with a few typos.
+// A limit of 0 returns all the matches.
+func (catalog *Catalog) Search(query string, limit int) []*models.Instrument {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	type match struct {
+		instrument *models.Instrument
+		score      int
+	}
+
+	var matches []match
+
+	for _, instrument := range catalog.instruments {
+		score := min(
+			searchScore(query, strings.ToLower(instrument.ShortName)),
+			searchScore(query, strings.ToLower(instrument.Name)),
+		)
+		if score != noMatch {
+			matches = append(matches, match{instrument: instrument, score: score})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b match) int {
+		return cmp.Or(cmp.Compare(a.score, b.score), strings.Compare(a.instrument.Name, b.instrument.Name))
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	results := make([]*models.Instrument, 0, len(matches))
+	for _, match := range matches {
+		results = append(results, match.instrument)
+	}
+
+	return results
+}
+
+const (
+	scoreExact = iota
+	scorePrefix
+	scoreWordPrefix
+	scoreSubstring
+	scoreTypos
+	noMatch = 1 << 30
+)
+
+// searchScore rates how well the lowercase name matches the lowercase query, lower is better.
+func searchScore(query, name string) int {
+	switch {
+	case name == "":
+		return noMatch
+	case name == query:
+		return scoreExact
+	case strings.HasPrefix(name, query):
+		return scorePrefix
+	case strings.Contains(name, " "+query):
+		return scoreWordPrefix
+	case strings.Contains(name, query):
+		return scoreSubstring
+	}
+
+	// typos, against the start of each word of the name
+	queryLength := len([]rune(query))
+
+	maxTypos := queryLength / 4 //nolint:mnd // one typo every four letters
+	if maxTypos == 0 {
+		return noMatch
+	}
+
+	best := noMatch
+	words := strings.Fields(name)
+
+	for index := range words {
+		rest := []rune(strings.Join(words[index:], " "))
+
+		distance := levenshtein(query, string(rest[:min(len(rest), queryLength)]))
+		if distance <= maxTypos {
+			best = min(best, scoreTypos+distance)
+		}
+	}
+
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+
+	for index := range previous {
+		previous[index] = index
+	}
+
+	for i := range source {
+		current[0] = i + 1
+
+		for j := range target {
+			cost := 1
+			if source[i] == target[j] {
+				cost = 0
+			}
+
+			current[j+1] = min(previous[j+1]+1, current[j]+1, previous[j]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
@@ -0,0 +1,205 @@
+package trading212
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+const (
+	catalogInstruments = `[
+		{"ticker": "AAPL_US_EQ", "isin": "US0378331005", "name": "Apple", "shortName": "AAPL",
+			"type": "STOCK", "currencyCode": "USD", "extendedHours": true, "workingScheduleId": 1},
+		{"ticker": "APC_DE_EQ", "isin": "US0378331005", "name": "Apple", "shortName": "APC",
+			"type": "STOCK", "currencyCode": "EUR", "extendedHours": false, "workingScheduleId": 2},
+		{"ticker": "AMAT_US_EQ", "isin": "US0382221051", "name": "Applied Materials", "shortName": "AMAT",
+			"type": "STOCK", "currencyCode": "USD", "extendedHours": true, "workingScheduleId": 1},
+		{"ticker": "MSFT_US_EQ", "isin": "US5949181045", "name": "Microsoft", "shortName": "MSFT",
+			"type": "STOCK", "currencyCode": "USD", "extendedHours": true, "workingScheduleId": 1},
+		{"ticker": "VUSAl_EQ", "isin": "IE00B3XXRP09", "name": "Vanguard S&P 500", "shortName": "VUSA",
+			"type": "ETF", "currencyCode": "GBP", "extendedHours": false, "workingScheduleId": 3}
+	]`
+	catalogExchanges = `[
+		{"id": 10, "name": "NASDAQ", "workingSchedules": [{"id": 1, "timeEvents": []}]},
+		{"id": 20, "name": "XETRA", "workingSchedules": [{"id": 2, "timeEvents": []}]}
+	]`
+)
+
+func newTestCatalog(t *testing.T) *Catalog {
+	t.Helper()
+
+	var instruments []*models.Instrument
+
+	var exchanges []*models.ExchangeMetadata
+
+	err := json.Unmarshal([]byte(catalogInstruments), &instruments)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	err = json.Unmarshal([]byte(catalogExchanges), &exchanges)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	return NewCatalog(slices.Values(instruments), slices.Values(exchanges))
+}
+
+func tickers(instruments []*models.Instrument) []string {
+	result := make([]string, 0, len(instruments))
+	for _, instrument := range instruments {
+		result = append(result, string(instrument.Ticker))
+	}
+
+	return result
+}
+
+func Test_Catalog_Lookups(t *testing.T) {
+	t.Parallel()
+
+	catalog := newTestCatalog(t)
+
+	if catalog.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", catalog.Len())
+	}
+
+	instrument, found := catalog.Instrument("MSFT_US_EQ")
+	if !found || instrument.Name != "Microsoft" {
+		t.Errorf("Instrument() = %v, %v, want Microsoft", instrument, found)
+	}
+
+	_, found = catalog.Instrument("UNKNOWN")
+	if found {
+		t.Errorf("Instrument() found an unknown ticker")
+	}
+
+	if got := tickers(catalog.ByISIN("us0378331005")); fmt.Sprint(got) != "[AAPL_US_EQ APC_DE_EQ]" {
+		t.Errorf("ByISIN() = %v, want both Apple listings", got)
+	}
+
+	exchange, found := catalog.Exchange(instrument)
+	if !found || exchange.Name != "NASDAQ" {
+		t.Errorf("Exchange() = %v, %v, want NASDAQ", exchange, found)
+	}
+
+	vusa, _ := catalog.Instrument("VUSAl_EQ")
+
+	_, found = catalog.Exchange(vusa)
+	if found {
+		t.Errorf("Exchange() found an unknown working schedule")
+	}
+}
+
+func Test_Catalog_Filter(t *testing.T) {
+	t.Parallel()
+
+	catalog := newTestCatalog(t)
+	extendedHours := false
+
+	tests := []struct {
+		name   string
+		filter InstrumentFilter
+		want   string
+	}{
+		{name: "no filter", filter: InstrumentFilter{}, want: "[AAPL_US_EQ APC_DE_EQ AMAT_US_EQ MSFT_US_EQ VUSAl_EQ]"},
+		{name: "type", filter: InstrumentFilter{Type: "etf"}, want: "[VUSAl_EQ]"},
+		{name: "currency", filter: InstrumentFilter{CurrencyCode: "EUR"}, want: "[APC_DE_EQ]"},
+		{
+			name:   "extended hours",
+			filter: InstrumentFilter{Type: "STOCK", ExtendedHours: &extendedHours},
+			want:   "[APC_DE_EQ]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tickers(slices.Collect(catalog.Filter(tt.filter))); fmt.Sprint(got) != tt.want {
+				t.Errorf("Filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Catalog_Search(t *testing.T) {
+	t.Parallel()
+
+	catalog := newTestCatalog(t)
+
+	tests := []struct {
+		name   string
+		search func() []*models.Instrument
+		want   string
+	}{
+		{
+			name:   "prefix on name and short name",
+			search: func() []*models.Instrument { return catalog.SearchPrefix("Ap") },
+			want:   "[APC_DE_EQ AAPL_US_EQ AMAT_US_EQ]",
+		},
+		{
+			name:   "prefix without match",
+			search: func() []*models.Instrument { return catalog.SearchPrefix("zz") },
+			want:   "[]",
+		},
+		{
+			name:   "exact short name first",
+			search: func() []*models.Instrument { return catalog.Search("msft", 0) },
+			want:   "[MSFT_US_EQ]",
+		},
+		{
+			name:   "prefix before word prefix",
+			search: func() []*models.Instrument { return catalog.Search("appl", 0) },
+			want:   "[AAPL_US_EQ APC_DE_EQ AMAT_US_EQ]",
+		},
+		{
+			name:   "word prefix",
+			search: func() []*models.Instrument { return catalog.Search("materials", 0) },
+			want:   "[AMAT_US_EQ]",
+		},
+		{
+			name:   "typos",
+			search: func() []*models.Instrument { return catalog.Search("mircosoft", 0) },
+			want:   "[MSFT_US_EQ]",
+		},
+		{
+			name:   "limit",
+			search: func() []*models.Instrument { return catalog.Search("apple", 1) },
+			want:   "[AAPL_US_EQ]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tickers(tt.search()); fmt.Sprint(got) != tt.want {
+				t.Errorf("search = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_LoadCatalog(t *testing.T) {
+	t.Parallel()
+
+	mockAPI := newRoutesMockAPI(t, map[APIEndpoint]http.HandlerFunc{
+		http.MethodGet + " " + GetExchangesMetadata:       serveBody(catalogExchanges),
+		http.MethodGet + " " + GetAllAvailableInstruments: serveBody(catalogInstruments),
+	})
+
+	catalog, err := LoadCatalog(context.Background(), mockAPI)
+	if err != nil {
+		t.Fatalf("LoadCatalog() error = %v", err)
+	}
+
+	instrument, _ := catalog.Instrument("APC_DE_EQ")
+
+	exchange, found := catalog.Exchange(instrument)
+	if catalog.Len() != 5 || !found || exchange.Name != "XETRA" {
+		t.Errorf("LoadCatalog() = %d instruments, exchange %v, want 5 and XETRA", catalog.Len(), exchange)
+	}
+}
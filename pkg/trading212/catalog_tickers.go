@@ -0,0 +1,88 @@
+package trading212
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+var errAmbiguousTicker = errors.New("ambiguous ticker, set the market")
+
+// isinPattern matches the ISIN, e.g. "US0378331005".
+var isinPattern = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{9}[0-9]$`)
+
+// index the instrument by symbol and by upper case ticker.
+func (catalog *Catalog) index(instrument *models.Instrument) {
+	symbol := strings.ToUpper(instrument.Ticker.Symbol())
+	catalog.bySymbol[symbol] = append(catalog.bySymbol[symbol], instrument)
+
+	folded := strings.ToUpper(string(instrument.Ticker))
+	catalog.byFoldedTicker[folded] = append(catalog.byFoldedTicker[folded], instrument)
+}
+
+// ISIN returns the ISIN of the ticker.
+func (catalog *Catalog) ISIN(ticker models.Ticker) (string, error) {
+	instrument, found := catalog.byTicker[ticker]
+	if !found {
+		return "", fmt.Errorf("%w: %s", errUnknownTicker, ticker)
+	}
+
+	return instrument.Isin, nil
+}
+
+// TickerByISIN returns the ticker of the ISIN on the market, e.g. "US" or "l" (see models.Ticker).
+// The market may be empty when the ISIN has a single listing.
+func (catalog *Catalog) TickerByISIN(isin, market string) (models.Ticker, error) {
+	return pickListing("ISIN "+isin, catalog.byISIN[strings.ToUpper(isin)], market)
+}
+
+// TickerBySymbol returns the ticker of a plain symbol on the market, e.g. "AAPL" on "US" is "AAPL_US_EQ".
+// The market may be empty when the symbol has a single listing.
+func (catalog *Catalog) TickerBySymbol(symbol, market string) (models.Ticker, error) {
+	return pickListing("symbol "+symbol, catalog.bySymbol[strings.ToUpper(symbol)], market)
+}
+
+// ResolveTicker turns a user input into the ticker of an available instrument. The input is either
+// a ticker, in any case, an ISIN or a plain symbol, the last two being looked up on the market.
+func (catalog *Catalog) ResolveTicker(input, market string) (models.Ticker, error) {
+	input = strings.TrimSpace(input)
+
+	if _, found := catalog.byTicker[models.Ticker(input)]; found {
+		return models.Ticker(input), nil
+	}
+
+	if listings, found := catalog.byFoldedTicker[strings.ToUpper(input)]; found {
+		return pickListing("ticker "+input, listings, "")
+	}
+
+	if isinPattern.MatchString(strings.ToUpper(input)) {
+		return catalog.TickerByISIN(input, market)
+	}
+
+	return catalog.TickerBySymbol(input, market)
+}
+
+// pickListing returns the ticker of the only listing on the market.
+func pickListing(query string, listings []*models.Instrument, market string) (models.Ticker, error) {
+	var tickers []models.Ticker
+
+	for _, instrument := range listings {
+		if market == "" || instrument.Ticker.IsMarket(market) {
+			tickers = append(tickers, instrument.Ticker)
+		}
+	}
+
+	switch {
+	case len(tickers) == 1:
+		return tickers[0], nil
+	case len(tickers) > 1:
+		return "", fmt.Errorf("%w: %s matches %v", errAmbiguousTicker, query, tickers)
+	case market != "":
+		return "", fmt.Errorf("%w: %s on market %s", errUnknownTicker, query, market)
+	default:
+		return "", fmt.Errorf("%w: %s", errUnknownTicker, query)
+	}
+}
@@ -0,0 +1,114 @@
+package trading212
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+func Test_Ticker_Parse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text           string
+		wantSymbol     string
+		wantMarket     string
+		wantAssetClass string
+		wantErr        bool
+	}{
+		{text: "AAPL_US_EQ", wantSymbol: "AAPL", wantMarket: "US", wantAssetClass: "EQ", wantErr: false},
+		{text: "BRK_B_US_EQ", wantSymbol: "BRK_B", wantMarket: "US", wantAssetClass: "EQ", wantErr: false},
+		{text: "VUSAl_EQ", wantSymbol: "VUSA", wantMarket: "l", wantAssetClass: "EQ", wantErr: false},
+		{text: "EURUSD_FX", wantSymbol: "EURUSD", wantMarket: "", wantAssetClass: "FX", wantErr: false},
+		{text: "AAPL", wantSymbol: "AAPL", wantMarket: "", wantAssetClass: "", wantErr: true},
+		{text: "_EQ", wantSymbol: "", wantMarket: "", wantAssetClass: "EQ", wantErr: true},
+		{text: "AAPL US EQ", wantSymbol: "AAPL US EQ", wantMarket: "", wantAssetClass: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			t.Parallel()
+
+			ticker, err := models.ParseTicker(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTicker() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if ticker.Symbol() != tt.wantSymbol || ticker.Market() != tt.wantMarket ||
+				ticker.AssetClass() != tt.wantAssetClass {
+				t.Errorf("ParseTicker() = %q %q %q, want %q %q %q", ticker.Symbol(), ticker.Market(),
+					ticker.AssetClass(), tt.wantSymbol, tt.wantMarket, tt.wantAssetClass)
+			}
+		})
+	}
+}
+
+func Test_NewTicker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		symbol  string
+		market  string
+		want    models.Ticker
+		wantErr bool
+	}{
+		{symbol: "aapl", market: "us", want: "AAPL_US_EQ", wantErr: false},
+		{symbol: "VUSA", market: "L", want: "VUSAl_EQ", wantErr: false},
+		{symbol: "", market: "US", want: "_US_EQ", wantErr: true},
+		{symbol: "AAPL US", market: "US", want: "AAPL US_US_EQ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol+" "+tt.market, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := models.NewTicker(tt.symbol, tt.market)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("NewTicker() = %q, %v, want %q, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_Catalog_Tickers(t *testing.T) {
+	t.Parallel()
+
+	catalog := newTestCatalog(t)
+
+	isin, err := catalog.ISIN("APC_DE_EQ")
+	if isin != "US0378331005" || err != nil {
+		t.Errorf("ISIN() = %q, %v, want US0378331005", isin, err)
+	}
+
+	_, err = catalog.ISIN("NOPE_US_EQ")
+	if !errors.Is(err, errUnknownTicker) {
+		t.Errorf("ISIN() error = %v, want %v", err, errUnknownTicker)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		market  string
+		want    models.Ticker
+		wantErr error
+	}{
+		{name: "ticker", input: "VUSAl_EQ", market: "", want: "VUSAl_EQ", wantErr: nil},
+		{name: "ticker in another case", input: " vusal_eq ", market: "", want: "VUSAl_EQ", wantErr: nil},
+		{name: "isin on a market", input: "us0378331005", market: "DE", want: "APC_DE_EQ", wantErr: nil},
+		{name: "isin on any market", input: "US0378331005", market: "", want: "", wantErr: errAmbiguousTicker},
+		{name: "single listing isin", input: "IE00B3XXRP09", market: "", want: "VUSAl_EQ", wantErr: nil},
+		{name: "symbol", input: "aapl", market: "", want: "AAPL_US_EQ", wantErr: nil},
+		{name: "symbol on an exchange", input: "VUSA", market: "L", want: "VUSAl_EQ", wantErr: nil},
+		{name: "symbol on another market", input: "AAPL", market: "DE", want: "", wantErr: errUnknownTicker},
+		{name: "unknown symbol", input: "NOPE", market: "", want: "", wantErr: errUnknownTicker},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := catalog.ResolveTicker(tt.input, tt.market)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("ResolveTicker() = %q, %v, want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package trading212
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var errUnexpectedJSON = errors.New("unexpected json value")
+
+// decodeStream decodes a response in a single pass, yielding the items as they are parsed.
+// The response is either an object, an array of objects, or a page of a paginated array
+// (an object with "items" and "nextPagePath"), detected from its first key.
+// It stops early, without reading the rest, when yield returns false.
+func decodeStream[T any](
+	reader io.Reader, options decoding, yield func(*T) bool,
+) (nextPagePath *string, stopped bool, err error) {
+	yield = attachingCurrency(yield)
+
+	decoder := json.NewDecoder(reader)
+	if options.strict() {
+		decoder.DisallowUnknownFields()
+	}
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, false, errors.Join(errDecodingResponse, err)
+	}
+
+	switch token {
+	case nil:
+		return nil, false, nil
+	case json.Delim('['):
+		stopped, err = decodeArray(decoder, options, yield)
+
+		return nil, stopped, err
+	case json.Delim('{'):
+		return decodeObject(decoder, reader, options, yield)
+	default:
+		return nil, false, fmt.Errorf("%w: %w: %v", errDecodingResponse, errUnexpectedJSON, token)
+	}
+}
+
+// currencyAttacher is implemented by the models holding amounts, to set their currency
+// from the sibling currency fields, see models.Money.
+type currencyAttacher interface {
+	AttachCurrency()
+}
+
+// attachingCurrency attaches the currencies of the items before yielding them.
+func attachingCurrency[T any](yield func(*T) bool) func(*T) bool {
+	return func(value *T) bool {
+		if attacher, ok := any(value).(currencyAttacher); ok && value != nil {
+			attacher.AttachCurrency()
+		}
+
+		return yield(value)
+	}
+}
+
+// decodeArray decodes the items of an array whose opening delimiter is already read.
+func decodeArray[T any](decoder *json.Decoder, options decoding, yield func(*T) bool) (bool, error) {
+	for decoder.More() {
+		var value *T
+
+		err := decodeValue(decoder, options, &value)
+		if err != nil {
+			return false, errors.Join(errDecodingResponse, err)
+		}
+
+		if !yield(value) {
+			return true, nil
+		}
+	}
+
+	// closing delimiter
+	_, err := decoder.Token()
+	if err != nil {
+		return false, errors.Join(errDecodingResponse, err)
+	}
+
+	return false, nil
+}
+
+// decodeObject decodes an object whose opening delimiter is already read,
+// either a paginated envelope or a single item.
+func decodeObject[T any](
+	decoder *json.Decoder, reader io.Reader, options decoding, yield func(*T) bool,
+) (*string, bool, error) {
+	if !decoder.More() {
+		var value T
+
+		return nil, !yield(&value), nil
+	}
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, false, errors.Join(errDecodingResponse, err)
+	}
+
+	key, _ := token.(string)
+	if key != "items" && key != "nextPagePath" {
+		return decodeItem(decoder, reader, key, options, yield)
+	}
+
+	return decodeEnvelope(decoder, key, options, yield)
+}
+
+// decodeItem decodes a single item object, whose opening delimiter and first key are already read.
+func decodeItem[T any](
+	decoder *json.Decoder, reader io.Reader, key string, options decoding, yield func(*T) bool,
+) (*string, bool, error) {
+	quotedKey, err := json.Marshal(key)
+	if err != nil {
+		return nil, false, errors.Join(errDecodingResponse, err)
+	}
+
+	// rewind to the start of the object
+	rewound := json.NewDecoder(io.MultiReader(
+		strings.NewReader("{"+string(quotedKey)), decoder.Buffered(), reader,
+	))
+	if options.strict() {
+		rewound.DisallowUnknownFields()
+	}
+
+	var value *T
+
+	err = decodeValue(rewound, options, &value)
+	if err != nil {
+		return nil, false, errors.Join(errDecodingResponse, err)
+	}
+
+	return nil, !yield(value), nil
+}
+
+// decodeEnvelope decodes a paginated envelope, whose opening delimiter and first key are already read.
+func decodeEnvelope[T any](
+	decoder *json.Decoder, key string, options decoding, yield func(*T) bool,
+) (*string, bool, error) {
+	var nextPagePath *string
+
+	for {
+		switch key {
+		case "items":
+			stopped, err := decodeItems(decoder, options, yield)
+			if err != nil || stopped {
+				return nil, stopped, err
+			}
+		case "nextPagePath":
+			err := decoder.Decode(&nextPagePath)
+			if err != nil {
+				return nil, false, errors.Join(errDecodingResponse, err)
+			}
+		default:
+			var skipped json.RawMessage
+
+			err := decoder.Decode(&skipped)
+			if err != nil {
+				return nil, false, errors.Join(errDecodingResponse, err)
+			}
+		}
+
+		if !decoder.More() {
+			return nextPagePath, false, nil
+		}
+
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, false, errors.Join(errDecodingResponse, err)
+		}
+
+		key, _ = token.(string)
+	}
+}
+
+// decodeItems decodes the "items" value of a paginated envelope, an array or null.
+func decodeItems[T any](decoder *json.Decoder, options decoding, yield func(*T) bool) (bool, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return false, errors.Join(errDecodingResponse, err)
+	}
+
+	switch token {
+	case nil:
+		return false, nil
+	case json.Delim('['):
+		return decodeArray(decoder, options, yield)
+	default:
+		return false, fmt.Errorf("%w: %w: items %v", errDecodingResponse, errUnexpectedJSON, token)
+	}
+}
+
+// decodeValue decodes the next item, checking it for unknown fields in lenient mode,
+// and for unknown enum values.
+func decodeValue[T any](decoder *json.Decoder, options decoding, value **T) error {
+	var err error
+	if !options.strict() {
+		err = decodeLenient(decoder, options, value)
+	} else {
+		err = decoder.Decode(value)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return checkEnums(options, *value)
+}
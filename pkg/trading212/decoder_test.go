@@ -0,0 +1,312 @@
+package trading212
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+func Test_decodeStream(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		data     string
+		limit    int
+		wantIDs  []uint
+		wantNext string
+		wantStop bool
+		wantErr  bool
+	}{
+		{name: "array", data: `[{"id": 1}, {"id": 2}]`, wantIDs: []uint{1, 2}},
+		{name: "empty array", data: `[]`, wantIDs: []uint{}},
+		{name: "null", data: `null`, wantIDs: []uint{}},
+		{name: "object", data: `{"id": 3, "ticker": "AAPL_US_EQ"}`, wantIDs: []uint{3}},
+		{name: "empty object", data: `{}`, wantIDs: []uint{0}},
+		{
+			name:     "paginated",
+			data:     `{"items": [{"id": 1}, {"id": 2}], "nextPagePath": "/next?cursor=2"}`,
+			wantIDs:  []uint{1, 2},
+			wantNext: "/next?cursor=2",
+		},
+		{
+			name:     "paginated, next page first",
+			data:     `{"nextPagePath": "/next", "items": [{"id": 1}]}`,
+			wantIDs:  []uint{1},
+			wantNext: "/next",
+		},
+		{name: "paginated, null items", data: `{"items": null, "nextPagePath": null}`, wantIDs: []uint{}},
+		{name: "stopped", data: `[{"id": 1}, {"id": 2}, {"id": 3}]`, limit: 2, wantIDs: []uint{1, 2}, wantStop: true},
+		{name: "unknown field", data: `[{"id": 1}, {"unknown": 2}]`, wantIDs: []uint{1}, wantErr: true},
+		{name: "unknown field in object", data: `{"id": 1, "unknown": 2}`, wantIDs: []uint{}, wantErr: true},
+		{name: "scalar", data: `42`, wantIDs: []uint{}, wantErr: true},
+		{name: "truncated", data: `[{"id": 1}, {"id"`, wantIDs: []uint{1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ids := []uint{}
+			strict := decoding{mode: DecodingStrict, drifts: nil}
+
+			next, stopped, err := decodeStream(strings.NewReader(tt.data), strict, func(order *models.Order) bool {
+				ids = append(ids, order.ID)
+
+				return tt.limit == 0 || len(ids) < tt.limit
+			})
+
+			if (err != nil) != tt.wantErr || stopped != tt.wantStop {
+				t.Fatalf("decodeStream() stopped = %v, error = %v, want %v, %v", stopped, err, tt.wantStop, tt.wantErr)
+			}
+
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIDs) {
+				t.Errorf("decodeStream() ids = %v, want %v", ids, tt.wantIDs)
+			}
+
+			gotNext := ""
+			if next != nil {
+				gotNext = *next
+			}
+
+			if gotNext != tt.wantNext {
+				t.Errorf("decodeStream() next = %q, want %q", gotNext, tt.wantNext)
+			}
+		})
+	}
+}
+
+const (
+	benchmarkInstrument = `{"addedOn": "2019-08-24T14:15:22Z", "currencyCode": "USD", "extendedHours": true, ` +
+		`"isin": "US0378331005", "maxOpenQuantity": 100, "name": "Apple", "shortName": "AAPL", ` +
+		`"ticker": "AAPL_US_EQ", "type": "STOCK", "workingScheduleId": 1}`
+	benchmarkOrderFill = `{"fill": {"filledAt": "2019-08-24T14:15:22Z", "id": 1, "price": 1, "quantity": 1, ` +
+		`"tradingMethod": "TOTV", "type": "TRADE", "walletImpact": {"currency": "EUR", "fxRate": 1, ` +
+		`"netValue": 1, "realisedProfitLoss": 0, "taxes": [{"chargedAt": "2019-08-24T14:15:22Z", ` +
+		`"currency": "EUR", "name": "COMMISSION_TURNOVER", "quantity": 0}]}}, "order": {"createdAt": ` +
+		`"2019-08-24T14:15:22Z", "currency": "EUR", "extendedHours": true, "filledQuantity": 1, ` +
+		`"filledValue": 1, "id": 1, "initiatedFrom": "API", "instrument": {"currency": "USD", "isin": ` +
+		`"US0378331005", "name": "Apple", "ticker": "AAPL_US_EQ"}, "limitPrice": 1, "quantity": 1, ` +
+		`"side": "BUY", "status": "FILLED", "stopPrice": 0, "strategy": "QUANTITY", "ticker": "AAPL_US_EQ", ` +
+		`"timeInForce": "DAY", "type": "LIMIT", "value": 1}}`
+)
+
+// legacyDecode is the former multi-pass decoding, kept as the benchmarks baseline.
+func legacyDecode[T any](raw []byte) ([]*T, error) {
+	var page struct {
+		Items        *json.RawMessage `json:"items"`
+		NextPagePath *string          `json:"nextPagePath"`
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	items := raw
+	if err := decoder.Decode(&page); err == nil && page.Items != nil {
+		items = *page.Items
+	}
+
+	var data []*T
+
+	decoder = json.NewDecoder(bytes.NewReader(items))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&data); err == nil {
+		return data, nil
+	}
+
+	var value *T
+
+	decoder = json.NewDecoder(bytes.NewReader(items))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(&value)
+
+	return []*T{value}, err
+}
+
+func benchmarkDecode[T any](b *testing.B, raw []byte, want int) {
+	b.Helper()
+
+	b.Run("stream", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(raw)))
+
+		for range b.N {
+			count := 0
+
+			_, _, err := decodeStream(bytes.NewReader(raw), decoding{mode: DecodingStrict, drifts: nil}, func(*T) bool {
+				count++
+
+				return true
+			})
+			if err != nil || count != want {
+				b.Fatalf("decodeStream() = %d items, %v", count, err)
+			}
+		}
+	})
+
+	b.Run("lenient", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(raw)))
+
+		lenient := decoding{mode: DecodingLenient, drifts: newSchemaDrifts()}
+
+		for range b.N {
+			count := 0
+
+			_, _, err := decodeStream(bytes.NewReader(raw), lenient, func(*T) bool {
+				count++
+
+				return true
+			})
+			if err != nil || count != want {
+				b.Fatalf("decodeStream() = %d items, %v", count, err)
+			}
+		}
+	})
+
+	b.Run("legacy", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(raw)))
+
+		for range b.N {
+			data, err := legacyDecode[T](raw)
+			if err != nil || len(data) != want {
+				b.Fatalf("legacyDecode() = %d items, %v", len(data), err)
+			}
+		}
+	})
+}
+
+func Benchmark_decodeStream_Instruments(b *testing.B) {
+	const count = 20000
+
+	raw := "[" + strings.TrimSuffix(strings.Repeat(benchmarkInstrument+",", count), ",") + "]"
+
+	benchmarkDecode[models.Instrument](b, []byte(raw), count)
+}
+
+func Benchmark_decodeStream_HistoricalOrders(b *testing.B) {
+	const count = 50
+
+	raw := `{"items": [` + strings.TrimSuffix(strings.Repeat(benchmarkOrderFill+",", count), ",") +
+		`], "nextPagePath": "/api/v0/equity/history/orders?cursor=1&limit=50"}`
+
+	benchmarkDecode[models.OrderFill](b, []byte(raw), count)
+}
+
+func Test_decodeStream_Money(t *testing.T) {
+	t.Parallel()
+
+	const data = `{"amount": 12.345678901234567890, "amountInEuro": 1e-7, "currency": "GBP",` +
+		` "grossAmountPerShare": 0.1, "instrument": {"currency": "USD"}, "quantity": 3.50}`
+
+	var dividend *models.Dividend
+
+	_, _, err := decodeStream(strings.NewReader(data), decoding{mode: DecodingStrict, drifts: nil},
+		func(value *models.Dividend) bool {
+			dividend = value
+
+			return true
+		},
+	)
+	if err != nil {
+		t.Fatalf("decodeStream() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  fmt.Stringer
+		want string
+	}{
+		{name: "amount", got: dividend.Amount, want: "12.345678901234567890 GBP"},
+		{name: "amount in euro", got: dividend.AmountInEuro, want: "0.0000001 EUR"},
+		{name: "gross amount per share", got: dividend.GrossAmountPerShare, want: "0.1 USD"},
+		{name: "quantity", got: dividend.Quantity, want: "3.50"},
+		{name: "total", got: dividend.GrossAmountPerShare.Mul(dividend.Quantity), want: "0.350 USD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	encoded, err := json.Marshal(dividend)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	for _, want := range []string{`"amount":12.345678901234567890`, `"amountInEuro":0.0000001`, `"quantity":3.50`} {
+		if !bytes.Contains(encoded, []byte(want)) {
+			t.Errorf("json.Marshal() = %s, want %s", encoded, want)
+		}
+	}
+}
+
+func Test_Decimal_Arithmetic(t *testing.T) {
+	t.Parallel()
+
+	parse := func(text string) models.Decimal {
+		decimal, err := models.ParseDecimal(text)
+		if err != nil {
+			t.Fatalf("ParseDecimal(%q) error = %v", text, err)
+		}
+
+		return decimal
+	}
+
+	quo := func(a, b models.Decimal) models.Decimal {
+		decimal, err := a.Quo(b, 4)
+		if err != nil {
+			t.Fatalf("Quo() error = %v", err)
+		}
+
+		return decimal
+	}
+
+	tests := []struct {
+		name string
+		got  models.Decimal
+		want string
+	}{
+		{name: "sum of tenths is exact", got: parse("0.1").Add(parse("0.2")), want: "0.3"},
+		{name: "sub keeps the scale", got: parse("1.50").Sub(parse("0.5")), want: "1.00"},
+		{name: "negative below one", got: parse("0.25").Sub(parse("1")), want: "-0.75"},
+		{name: "mul", got: parse("-1.5").Mul(parse("2.25")), want: "-3.375"},
+		{name: "quo rounds half away from zero", got: quo(parse("2"), parse("3")), want: "0.6667"},
+		{name: "negative quo", got: quo(parse("-1"), parse("8")), want: "-0.1250"},
+		{name: "round", got: parse("-2.345").Round(2), want: "-2.35"},
+		{name: "exponent", got: parse("15E2"), want: "1500"},
+		{name: "from float", got: models.MustDecimal(0.1), want: "0.1"},
+		{name: "zero value", got: models.Decimal{}, want: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	for _, text := range []string{"", "-", "1.2.3", "1e", "abc", "--1", "1e999999999", "1e-1001", "1e99999999999"} {
+		if _, err := models.ParseDecimal(text); err == nil {
+			t.Errorf("ParseDecimal(%q) should fail", text)
+		}
+	}
+
+	if _, err := models.NewMoney(parse("1"), "USD").Add(models.NewMoney(parse("1"), "EUR")); err == nil {
+		t.Errorf("Money.Add() should fail across currencies")
+	}
+}
@@ -0,0 +1,549 @@
+package trading212
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DecodingMode of the API responses.
+type DecodingMode int
+
+const (
+	// DecodingLenient ignores the fields unknown to the models, reporting them as SchemaDrift.
+	// This is the default, so new fields added by Trading212 do not break the client.
+	DecodingLenient DecodingMode = iota
+	// DecodingStrict fails on the fields unknown to the models, best suited for tests.
+	DecodingStrict
+)
+
+var errUnknownEnumValue = errors.New("unknown enum value")
+
+// SchemaDrift is a field returned by the API that is unknown to the models,
+// or an enum field holding a value unknown to the models, e.g. a new models.OrderStatus.
+type SchemaDrift struct {
+	// Type of the model, e.g. "models.Position".
+	Type string
+	// Field path in the json object, e.g. "walletImpact.newField".
+	Field string
+	// Value of the enum field, empty for an unknown field.
+	Value string
+}
+
+// SchemaDriftHandler is called once for each SchemaDrift found in lenient mode.
+type SchemaDriftHandler func(drift SchemaDrift)
+
+// schemaDrifts collects the SchemaDrift, reporting each one once.
+type schemaDrifts struct {
+	mutex   sync.Mutex
+	seen    map[SchemaDrift]struct{}
+	handler SchemaDriftHandler
+	logger  *slog.Logger
+}
+
+func newSchemaDrifts() *schemaDrifts {
+	return &schemaDrifts{
+		mutex:   sync.Mutex{},
+		seen:    map[SchemaDrift]struct{}{},
+		handler: nil,
+		logger:  slog.Default(),
+	}
+}
+
+func (drifts *schemaDrifts) report(drift SchemaDrift) {
+	drifts.mutex.Lock()
+	_, seen := drifts.seen[drift]
+	drifts.seen[drift] = struct{}{}
+	drifts.mutex.Unlock()
+
+	if seen {
+		return
+	}
+
+	if drift.Value != "" {
+		drifts.logger.Warn("Unknown enum value in API response",
+			"type", drift.Type, "field", drift.Field, "value", drift.Value)
+	} else {
+		drifts.logger.Warn("Unknown field in API response", "type", drift.Type, "field", drift.Field)
+	}
+
+	if drifts.handler != nil {
+		drifts.handler(drift)
+	}
+}
+
+func (drifts *schemaDrifts) list() []SchemaDrift {
+	drifts.mutex.Lock()
+	defer drifts.mutex.Unlock()
+
+	list := make([]SchemaDrift, 0, len(drifts.seen))
+	for drift := range drifts.seen {
+		list = append(list, drift)
+	}
+
+	slices.SortFunc(list, func(a, b SchemaDrift) int {
+		return cmp.Or(strings.Compare(a.Type+"."+a.Field, b.Type+"."+b.Field), strings.Compare(a.Value, b.Value))
+	})
+
+	return list
+}
+
+// SchemaDrifts returns the fields and enum values unknown to the models found so far,
+// sorted by type, field and value.
+func (api *API) SchemaDrifts() []SchemaDrift {
+	return api.schemaDrifts.list()
+}
+
+// decoding of the responses of a client.
+// The zero value is strict.
+type decoding struct {
+	mode   DecodingMode
+	drifts *schemaDrifts
+}
+
+func (api *API) responseDecoding() decoding {
+	return decoding{mode: api.decodingMode, drifts: api.schemaDrifts}
+}
+
+// strict reports whether unknown fields are errors.
+func (d decoding) strict() bool {
+	return d.mode == DecodingStrict || d.drifts == nil
+}
+
+// decodeLenient decodes an item of a response in lenient mode, checking it for unknown fields.
+// Items may have optional fields the others lack, so each one is checked, the drifts being reported once.
+func decodeLenient[T any](decoder *json.Decoder, d decoding, value **T) error {
+	raw := rawValues.Get().(*json.RawMessage) //nolint:forcetypeassert // only raw values are pooled
+	defer rawValues.Put(raw)
+
+	err := decoder.Decode(raw)
+	if err != nil {
+		return err
+	}
+
+	typ := reflect.TypeFor[T]()
+	scanner := fieldScanner{data: *raw, pos: 0, path: nil, report: func(field string) {
+		d.drifts.report(SchemaDrift{Type: typ.String(), Field: field, Value: ""})
+	}}
+	scanner.value(newScanTarget(typ))
+
+	return json.Unmarshal(*raw, value)
+}
+
+// rawValues reuses the buffers of the items decoded in lenient mode, json.Unmarshal copying what it keeps.
+var rawValues = sync.Pool{New: func() any { return &json.RawMessage{} }}
+
+// unknownFields lists the keys of the json object that have no matching field in typ.
+func unknownFields(raw json.RawMessage, typ reflect.Type) []string {
+	var unknown []string
+
+	scanner := fieldScanner{data: raw, pos: 0, path: nil, report: func(field string) {
+		unknown = append(unknown, field)
+	}}
+	scanner.value(newScanTarget(typ))
+
+	slices.Sort(unknown)
+
+	return slices.Compact(unknown)
+}
+
+var unmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// scanTarget is the value expected by the fieldScanner: an object of a struct, an array,
+// or any other value, skipped.
+type scanTarget struct {
+	typ reflect.Type
+	// kind is reflect.Struct, reflect.Slice, or reflect.Invalid for a value to skip.
+	kind reflect.Kind
+}
+
+func newScanTarget(typ reflect.Type) scanTarget {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	// custom decoding, e.g. time.Time
+	if reflect.PointerTo(typ).Implements(unmarshalerType) {
+		return scanTarget{typ: typ, kind: reflect.Invalid}
+	}
+
+	switch typ.Kind() { //nolint:exhaustive // only objects can have unknown fields
+	case reflect.Struct:
+		return scanTarget{typ: typ, kind: reflect.Struct}
+	case reflect.Slice, reflect.Array:
+		return scanTarget{typ: typ, kind: reflect.Slice}
+	default:
+		return scanTarget{typ: typ, kind: reflect.Invalid}
+	}
+}
+
+// fieldScanner walks a valid json value in a single pass, reporting the keys that have no matching
+// field in the models, without decoding the values.
+type fieldScanner struct {
+	data []byte
+	pos  int
+	// path of the raw keys leading to the current value, only joined to report a field.
+	path   [][]byte
+	report func(field string)
+}
+
+// value scans the value at the current position.
+func (s *fieldScanner) value(target scanTarget) {
+	s.space()
+
+	switch {
+	case target.kind == reflect.Struct && s.peek() == '{':
+		s.object(fieldIndexOf(target.typ))
+	case target.kind == reflect.Slice && s.peek() == '[':
+		s.array(newScanTarget(target.typ.Elem()))
+	default:
+		s.skip()
+	}
+}
+
+func (s *fieldScanner) object(index *fieldIndex) {
+	s.pos++ // opening delimiter
+
+	for {
+		s.space()
+
+		switch s.peek() {
+		case 0:
+			return
+		case '}':
+			s.pos++
+
+			return
+		case ',':
+			s.pos++
+
+			continue
+		}
+
+		key := s.string()
+		s.space()
+		s.pos++ // colon
+
+		s.path = append(s.path, key)
+
+		if target, found := index.lookup(key); found {
+			s.value(target)
+		} else {
+			s.report(s.field())
+			s.skip()
+		}
+
+		s.path = s.path[:len(s.path)-1]
+	}
+}
+
+func (s *fieldScanner) array(elem scanTarget) {
+	s.pos++ // opening delimiter
+
+	for {
+		s.space()
+
+		switch s.peek() {
+		case 0:
+			return
+		case ']':
+			s.pos++
+
+			return
+		case ',':
+			s.pos++
+
+			continue
+		}
+
+		s.value(elem)
+	}
+}
+
+// skip the value at the current position.
+func (s *fieldScanner) skip() {
+	s.space()
+
+	switch s.peek() {
+	case '"':
+		s.string()
+	case '{', '[':
+		depth := 0
+
+		for s.pos < len(s.data) {
+			switch s.data[s.pos] {
+			case '"':
+				s.string()
+
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+
+			s.pos++
+
+			if depth == 0 {
+				return
+			}
+		}
+	default:
+		for s.pos < len(s.data) && strings.IndexByte(",}] \t\r\n", s.data[s.pos]) < 0 {
+			s.pos++
+		}
+	}
+}
+
+// string returns the raw content of the string at the current position, still escaped.
+func (s *fieldScanner) string() []byte {
+	start := s.pos + 1
+
+	for s.pos++; s.pos < len(s.data); s.pos++ {
+		switch s.data[s.pos] {
+		case '\\':
+			s.pos++
+		case '"':
+			s.pos++
+
+			return s.data[start : s.pos-1]
+		}
+	}
+
+	return s.data[start:]
+}
+
+func (s *fieldScanner) space() {
+	for s.pos < len(s.data) && strings.IndexByte(" \t\r\n", s.data[s.pos]) >= 0 {
+		s.pos++
+	}
+}
+
+// peek returns the byte at the current position, 0 at the end.
+func (s *fieldScanner) peek() byte {
+	if s.pos >= len(s.data) {
+		return 0
+	}
+
+	return s.data[s.pos]
+}
+
+// field returns the path of the current key, e.g. "walletImpact.newField".
+func (s *fieldScanner) field() string {
+	names := make([]string, 0, len(s.path))
+	for _, key := range s.path {
+		names = append(names, keyName(key))
+	}
+
+	return strings.Join(names, ".")
+}
+
+// keyName unescapes a raw key.
+func keyName(key []byte) string {
+	if bytes.IndexByte(key, '\\') < 0 {
+		return string(key)
+	}
+
+	var name string
+
+	_ = json.Unmarshal(append(append([]byte{'"'}, key...), '"'), &name)
+
+	return name
+}
+
+// fieldIndex maps the json names of the fields of a struct to what they hold, built once per type.
+type fieldIndex struct {
+	// fields by their json name.
+	fields map[string]scanTarget
+	// folded fields by their lowercase json name, encoding/json matching the keys case-insensitively.
+	folded map[string]scanTarget
+}
+
+// fieldIndexes caches the fieldIndex of the struct types.
+var fieldIndexes sync.Map
+
+func fieldIndexOf(typ reflect.Type) *fieldIndex {
+	if cached, ok := fieldIndexes.Load(typ); ok {
+		return cached.(*fieldIndex) //nolint:forcetypeassert // only indexes are stored
+	}
+
+	index := &fieldIndex{fields: map[string]scanTarget{}, folded: map[string]scanTarget{}}
+	for name, fieldType := range jsonFields(typ) {
+		index.fields[name] = newScanTarget(fieldType)
+		index.folded[strings.ToLower(name)] = index.fields[name]
+	}
+
+	fieldIndexes.Store(typ, index)
+
+	return index
+}
+
+// lookup returns what the field matching the raw key holds.
+func (index *fieldIndex) lookup(key []byte) (scanTarget, bool) {
+	if target, found := index.fields[string(key)]; found {
+		return target, true
+	}
+
+	target, found := index.folded[strings.ToLower(keyName(key))]
+
+	return target, found
+}
+
+// jsonFields maps the json names of the fields of a struct to their type,
+// including the fields promoted from embedded structs.
+func jsonFields(typ reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+
+	for index := range typ.NumField() {
+		field := typ.Field(index)
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			maps.Copy(fields, jsonFields(fieldType))
+
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields[name] = field.Type
+	}
+
+	return fields
+}
+
+// knownEnum is implemented by the enums of the models, e.g. models.OrderStatus.
+type knownEnum interface {
+	IsKnown() bool
+}
+
+var (
+	knownEnumType = reflect.TypeFor[knownEnum]()
+	// enumHolders caches whether a type holds enums, most do not and are not walked.
+	enumHolders sync.Map
+)
+
+// checkEnums reports the enum values unknown to the models of a decoded item,
+// as SchemaDrift in lenient mode, or as an error in strict mode.
+func checkEnums[T any](d decoding, value *T) error {
+	typ := reflect.TypeFor[T]()
+	if value == nil || !holdsEnums(typ) {
+		return nil
+	}
+
+	unknown := unknownEnums(reflect.ValueOf(value).Elem(), "")
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	if d.strict() {
+		return fmt.Errorf("%w: %s %q", errUnknownEnumValue, unknown[0].Field, unknown[0].Value)
+	}
+
+	for _, drift := range unknown {
+		drift.Type = typ.String()
+		d.drifts.report(drift)
+	}
+
+	return nil
+}
+
+// holdsEnums reports whether values of the type may contain an enum.
+func holdsEnums(typ reflect.Type) bool {
+	if cached, ok := enumHolders.Load(typ); ok {
+		return cached.(bool) //nolint:forcetypeassert // only bools are stored
+	}
+
+	holds := false
+
+	switch typ.Kind() { //nolint:exhaustive // only these kinds can hold an enum
+	case reflect.String:
+		holds = typ.Implements(knownEnumType)
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		holds = holdsEnums(typ.Elem())
+	case reflect.Struct:
+		for index := range typ.NumField() {
+			holds = holds || holdsEnums(typ.Field(index).Type)
+		}
+	}
+
+	enumHolders.Store(typ, holds)
+
+	return holds
+}
+
+// unknownEnums lists the non-empty enum values unknown to the models, with their json path.
+func unknownEnums(value reflect.Value, prefix string) []SchemaDrift {
+	if !holdsEnums(value.Type()) {
+		return nil
+	}
+
+	switch value.Kind() { //nolint:exhaustive // only these kinds can hold an enum
+	case reflect.String:
+		// converted, as the values of unexported embedded structs cannot be used as interfaces
+		enum, _ := reflect.ValueOf(value.String()).Convert(value.Type()).Interface().(knownEnum)
+		if value.String() == "" || enum.IsKnown() {
+			return nil
+		}
+
+		return []SchemaDrift{{Type: "", Field: strings.TrimSuffix(prefix, "."), Value: value.String()}}
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil
+		}
+
+		return unknownEnums(value.Elem(), prefix)
+	case reflect.Slice, reflect.Array:
+		var unknown []SchemaDrift
+		for index := range value.Len() {
+			unknown = append(unknown, unknownEnums(value.Index(index), prefix)...)
+		}
+
+		return unknown
+	case reflect.Struct:
+		var unknown []SchemaDrift
+
+		for index := range value.NumField() {
+			field := value.Type().Field(index)
+
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" || (!field.Anonymous && !field.IsExported()) {
+				continue
+			}
+
+			path := prefix
+			if !field.Anonymous || name != "" {
+				path += cmp.Or(name, field.Name) + "."
+			}
+
+			unknown = append(unknown, unknownEnums(value.Field(index), path)...)
+		}
+
+		return unknown
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,309 @@
+package trading212
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+func Test_unknownFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		typ  reflect.Type
+		data string
+		want []string
+	}{
+		{
+			name: "known fields",
+			typ:  reflect.TypeFor[models.Transaction](),
+			data: `{"amount": 1, "currency": "EUR", "dateTime": "2019-08-24T14:15:22Z", "Reference": "a"}`,
+			want: nil,
+		},
+		{
+			name: "unknown top-level fields",
+			typ:  reflect.TypeFor[models.Transaction](),
+			data: `{"amount": 1, "newField": true, "another": null}`,
+			want: []string{"another", "newField"},
+		},
+		{
+			name: "unknown nested fields",
+			typ:  reflect.TypeFor[models.OrderFill](),
+			data: `{"fill": {"walletImpact": {"taxes": [{"name": "FEE", "rate": 1}], "newField": 1}}, ` +
+				`"order": {"ticker": "AAPL_US_EQ", "instrument": {"ticker": "AAPL_US_EQ", "exchange": "NYSE"}}}`,
+			want: []string{"fill.walletImpact.newField", "fill.walletImpact.taxes.rate", "order.instrument.exchange"},
+		},
+		{
+			name: "unknown fields of any array item",
+			typ:  reflect.TypeFor[models.OrderFill](),
+			data: `{"fill": {"walletImpact": {"taxes": [{"name": "FEE"}, {"name": "FEE", "rate": 1}, {"rate": 2}]}}}`,
+			want: []string{"fill.walletImpact.taxes.rate"},
+		},
+		{
+			name: "escaped and case-insensitive keys",
+			typ:  reflect.TypeFor[models.Transaction](),
+			data: `{"AMOUNT": 1, "c\u0075rrency": "EUR", "n\u0065w": 1}`,
+			want: []string{"new"},
+		},
+		{
+			name: "delimiters in skipped values",
+			typ:  reflect.TypeFor[models.Transaction](),
+			data: `{"reference": "a}]\"{", "newField": [1, {"other": "}"}, "]"], "amount": -1.5e3, "type": null}`,
+			want: []string{"newField"},
+		},
+		{
+			name: "embedded structs",
+			typ:  reflect.TypeFor[models.Report](),
+			data: `{"reportId": 1, "dataIncluded": {"includeOrders": true}, "timeFrom": "2019-08-24T14:15:22Z", ` +
+				`"status": "Finished", "format": "csv"}`,
+			want: []string{"format"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := unknownFields([]byte(tt.data), tt.typ)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("unknownFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_unknownEnums(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "known and empty values",
+			data: `{"order": {"status": "FILLED", "side": "BUY", "type": ""}}`,
+			want: "[]",
+		},
+		{
+			name: "unknown nested value",
+			data: `{"order": {"status": "EXPIRED", "side": "BUY", "timeInForce": "IMMEDIATE_OR_CANCEL"}}`,
+			want: "[{ order.status EXPIRED} { order.timeInForce IMMEDIATE_OR_CANCEL}]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var fill models.OrderFill
+
+			err := json.Unmarshal([]byte(tt.data), &fill)
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			got := unknownEnums(reflect.ValueOf(fill), "")
+			if fmt.Sprint(got) != tt.want {
+				t.Errorf("unknownEnums() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var report models.Report
+
+	report.Status = "Expired"
+
+	got := unknownEnums(reflect.ValueOf(report), "")
+	if fmt.Sprint(got) != "[{ status Expired}]" {
+		t.Errorf("unknownEnums() of embedded structs = %v, want status Expired", got)
+	}
+}
+
+func Test_Decoding_Modes(t *testing.T) {
+	t.Parallel()
+
+	const positions = `[{"quantity": 1, "newField": 1}, {"quantity": 2, "newField": 2, "otherField": 3}]`
+
+	tests := []struct {
+		name       string
+		mode       DecodingMode
+		wantCount  int
+		wantErr    bool
+		wantDrifts []SchemaDrift
+	}{
+		{
+			name:       "strict should fail on unknown fields",
+			mode:       DecodingStrict,
+			wantCount:  0,
+			wantErr:    true,
+			wantDrifts: []SchemaDrift{},
+		},
+		{
+			name:      "lenient should report unknown fields of every item once",
+			mode:      DecodingLenient,
+			wantCount: 2,
+			wantErr:   false,
+			wantDrifts: []SchemaDrift{
+				{Type: "models.Position", Field: "newField"}, {Type: "models.Position", Field: "otherField"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockAPI, terminate, err := newMockAPI(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, _ *http.Request) {
+						_, _ = fmt.Fprint(w, positions)
+					},
+				),
+			)
+			defer terminate()
+
+			if err != nil {
+				t.Fatalf("Error creating mock api; %v", err)
+			}
+
+			var mutex sync.Mutex
+
+			handled := []SchemaDrift{}
+			options := []Option{
+				WithDecodingMode(tt.mode),
+				WithSchemaDriftHandler(func(drift SchemaDrift) {
+					mutex.Lock()
+					defer mutex.Unlock()
+
+					handled = append(handled, drift)
+				}),
+			}
+
+			for _, option := range options {
+				err = option(mockAPI)
+				if err != nil {
+					t.Fatalf("Option() error = %v", err)
+				}
+			}
+
+			// twice, to check the drifts are only reported once
+			for range 2 {
+				count := 0
+				for _, err = range runOperation[models.Position](
+					context.Background(), mockAPI, http.MethodGet, GetAllPositions, nil,
+				).All() {
+					if err != nil {
+						break
+					}
+
+					count++
+				}
+
+				if count != tt.wantCount || (err != nil) != tt.wantErr {
+					t.Errorf("All() = %d items, error %v, want %d items, error %v", count, err, tt.wantCount, tt.wantErr)
+				}
+
+				if tt.wantErr && !errors.Is(err, errDecodingResponse) {
+					t.Errorf("All() error = %v, want %v", err, errDecodingResponse)
+				}
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if !reflect.DeepEqual(handled, tt.wantDrifts) {
+				t.Errorf("handled drifts = %v, want %v", handled, tt.wantDrifts)
+			}
+
+			if got := mockAPI.SchemaDrifts(); fmt.Sprint(got) != fmt.Sprint(tt.wantDrifts) {
+				t.Errorf("SchemaDrifts() = %v, want %v", got, tt.wantDrifts)
+			}
+		})
+	}
+}
+
+func Test_Decoding_UnknownEnums(t *testing.T) {
+	t.Parallel()
+
+	const orders = `[{"id": 1, "status": "EXPIRED", "type": "LIMIT"}, {"id": 2, "status": "EXPIRED"}]`
+
+	tests := []struct {
+		name       string
+		mode       DecodingMode
+		wantCount  int
+		wantErr    error
+		wantDrifts []SchemaDrift
+	}{
+		{
+			name:       "strict should fail on unknown values",
+			mode:       DecodingStrict,
+			wantCount:  0,
+			wantErr:    errUnknownEnumValue,
+			wantDrifts: []SchemaDrift{},
+		},
+		{
+			name:       "lenient should keep and report unknown values once",
+			mode:       DecodingLenient,
+			wantCount:  2,
+			wantErr:    nil,
+			wantDrifts: []SchemaDrift{{Type: "models.Order", Field: "status", Value: "EXPIRED"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockAPI, terminate, err := newMockAPI(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, _ *http.Request) {
+						_, _ = fmt.Fprint(w, orders)
+					},
+				),
+			)
+			defer terminate()
+
+			if err != nil {
+				t.Fatalf("Error creating mock api; %v", err)
+			}
+
+			err = WithDecodingMode(tt.mode)(mockAPI)
+			if err != nil {
+				t.Fatalf("Option() error = %v", err)
+			}
+
+			count := 0
+			for order, err := range runOperation[models.Order](
+				context.Background(), mockAPI, http.MethodGet, GetAllPendingOrders, nil,
+			).All() {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("All() error = %v, want %v", err, tt.wantErr)
+				}
+
+				if err != nil {
+					break
+				}
+
+				if order.Status != "EXPIRED" || order.Status.IsKnown() || order.Status.IsActive() {
+					t.Errorf("Status = %q, known %v, want EXPIRED, unknown and inactive",
+						order.Status, order.Status.IsKnown())
+				}
+
+				count++
+			}
+
+			if count != tt.wantCount {
+				t.Errorf("All() = %d items, want %d", count, tt.wantCount)
+			}
+
+			if got := mockAPI.SchemaDrifts(); fmt.Sprint(got) != fmt.Sprint(tt.wantDrifts) {
+				t.Errorf("SchemaDrifts() = %v, want %v", got, tt.wantDrifts)
+			}
+		})
+	}
+}
@@ -1,5 +1,11 @@
 package trading212
 
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
 // APIEndpoint type.
 type APIEndpoint string
 
@@ -71,3 +77,56 @@ const (
 	// DuplicatePie endpoint.
 	DuplicatePie = endpointBase + "/pies" // + /{id}/duplicate
 )
+
+// isIdempotent reports whether a request can be safely sent again after an ambiguous failure.
+// Order placements are not: the first attempt may have been accepted, and a retry would
+// create a duplicate order.
+func isIdempotent(method string, path APIEndpoint) bool {
+	if method != http.MethodPost {
+		return true
+	}
+
+	switch path {
+	case PlaceLimitOrder, PlaceMarketOrder, PlaceStopOrder, PlaceStopLimitOrder:
+		return false
+	default:
+		return true
+	}
+}
+
+// isPaginated reports whether the endpoint returns its items by pages, accepting a "limit" parameter.
+func isPaginated(method string, path APIEndpoint) bool {
+	if method != http.MethodGet {
+		return false
+	}
+
+	switch path {
+	case GetDividends, GetHistoricalOrders, GetTransactions:
+		return true
+	default:
+		return false
+	}
+}
+
+// withQuery returns the endpoint with the encoded query parameters appended.
+func (endpoint APIEndpoint) withQuery(values url.Values) APIEndpoint {
+	if len(values) == 0 {
+		return endpoint
+	}
+
+	return endpoint + "?" + APIEndpoint(values.Encode())
+}
+
+// Template returns the endpoint with its identifiers replaced by "{id}",
+// e.g. "/api/v0/equity/orders/123" becomes "/api/v0/equity/orders/{id}".
+// Trading212 applies its rate-limits per endpoint template.
+func (endpoint APIEndpoint) Template() APIEndpoint {
+	segments := strings.Split(string(endpoint), "/")
+	for index, segment := range segments {
+		if segment != "" && strings.Trim(segment, "0123456789") == "" {
+			segments[index] = "{id}"
+		}
+	}
+
+	return APIEndpoint(strings.Join(segments, "/"))
+}
@@ -0,0 +1,31 @@
+package trading212
+
+import (
+	"testing"
+)
+
+func Test_APIEndpoint_Template(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		endpoint APIEndpoint
+		want     APIEndpoint
+	}{
+		{endpoint: GetAllPendingOrders, want: "/api/v0/equity/orders"},
+		{endpoint: GetPendingOrderByID + "/123", want: "/api/v0/equity/orders/{id}"},
+		{endpoint: DuplicatePie + "/42/duplicate", want: "/api/v0/equity/pies/{id}/duplicate"},
+		{endpoint: PlaceStopLimitOrder, want: PlaceStopLimitOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			string(tt.endpoint), func(t *testing.T) {
+				t.Parallel()
+
+				if got := tt.endpoint.Template(); got != tt.want {
+					t.Errorf("Template() = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
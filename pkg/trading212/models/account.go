@@ -8,25 +8,33 @@ type AccountSummary struct {
 	// Primary account currency in ISO 4217 format.
 	Currency string `json:"currency"`
 	// Investments value in your account's primary currency.
-	TotalValue float64 `json:"totalValue"`
+	TotalValue Money `json:"totalValue"`
 	Cash       struct {
 		// Funds available for investing.
-		AvailableToTrade float64 `json:"availableToTrade"`
+		AvailableToTrade Money `json:"availableToTrade"`
 		// The amount of cash reserved for pending orders. This cash is not available for placing new trades.
-		ReservedForOrders float64 `json:"reservedForOrders"`
+		ReservedForOrders Money `json:"reservedForOrders"`
 		// The amount of cash in Pies.
-		InPies float64 `json:"inPies"`
+		InPies Money `json:"inPies"`
 	} `json:"cash"`
 	Investments struct {
 		// Current value of all the investments.
-		CurrentValue float64 `json:"currentValue"`
+		CurrentValue Money `json:"currentValue"`
 		// The all-time realised profit loss from all the trades executed.
-		TotalCost float64 `json:"totalCost"`
+		TotalCost Money `json:"totalCost"`
 		// The cost basis of your current investments.
 		// The total amount of funds you've invested in the shares you currently own.
-		RealizedProfitLoss float64 `json:"realizedProfitLoss"`
+		RealizedProfitLoss Money `json:"realizedProfitLoss"`
 		// The potential profit/loss of your current investments,
 		// showing how much you could gain or lose if you were to sell them now.
-		UnrealizedProfitLoss float64 `json:"unrealizedProfitLoss"`
+		UnrealizedProfitLoss Money `json:"unrealizedProfitLoss"`
 	} `json:"investments"`
 }
+
+// AttachCurrency sets the account currency to the amounts, done by the client once decoded.
+func (summary *AccountSummary) AttachCurrency() {
+	withCurrency(summary.Currency, &summary.TotalValue,
+		&summary.Cash.AvailableToTrade, &summary.Cash.ReservedForOrders, &summary.Cash.InPies,
+		&summary.Investments.CurrentValue, &summary.Investments.TotalCost,
+		&summary.Investments.RealizedProfitLoss, &summary.Investments.UnrealizedProfitLoss)
+}
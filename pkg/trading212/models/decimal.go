@@ -0,0 +1,305 @@
+package models
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	errDecimalSyntax    = errors.New("invalid decimal")
+	errDecimalNotFinite = errors.New("decimal should be finite")
+	errDivisionByZero   = errors.New("division by zero")
+	errDecimalRange     = fmt.Errorf("decimal exponent should be within -%d and %d", maxDecimalScale, maxDecimalScale)
+)
+
+// maxDecimalScale bounds the exponent of the parsed decimals, far beyond any price or quantity,
+// as the digits of 1e999999999 would not fit in memory.
+const maxDecimalScale = 1000
+
+// Decimal is an exact decimal number, decoded from and encoded to json numbers without rounding.
+// The zero value is 0.
+type Decimal struct {
+	// coefficient of the number, nil for 0.
+	coefficient *big.Int
+	// scale is the number of digits after the point.
+	scale int32
+}
+
+// Quantity is a number of shares.
+type Quantity = Decimal
+
+// NewDecimal returns coefficient * 10^-scale, e.g. NewDecimal(15005, 2) is 150.05.
+func NewDecimal(coefficient int64, scale int32) Decimal {
+	return Decimal{coefficient: big.NewInt(coefficient), scale: scale}.normalize()
+}
+
+// DecimalFromInt returns the integer as a decimal.
+func DecimalFromInt(value int64) Decimal {
+	return NewDecimal(value, 0)
+}
+
+// DecimalFromFloat returns the shortest decimal representing the float, e.g. 0.1 is exactly 0.1.
+func DecimalFromFloat(value float64) (Decimal, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Decimal{}, fmt.Errorf("%w: got %v", errDecimalNotFinite, value)
+	}
+
+	return ParseDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// MustDecimal is DecimalFromFloat, panicking on non-finite values. Meant for constants.
+func MustDecimal(value float64) Decimal {
+	decimal, err := DecimalFromFloat(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return decimal
+}
+
+// ParseDecimal parses a decimal in the json number syntax, e.g. "-150.05" or "1.5e-3".
+// The number of digits after the point, once the exponent applied, should be within -1000 and 1000.
+func ParseDecimal(text string) (Decimal, error) {
+	mantissa, exponent, hasExponent := strings.Cut(strings.ToLower(text), "e")
+
+	negative := strings.HasPrefix(mantissa, "-")
+	if negative || strings.HasPrefix(mantissa, "+") {
+		mantissa = mantissa[1:]
+	}
+
+	integer, fraction, _ := strings.Cut(mantissa, ".")
+
+	digits := integer + fraction
+	if digits == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("%w: %q", errDecimalSyntax, text)
+	}
+
+	coefficient, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("%w: %q", errDecimalSyntax, text)
+	}
+
+	if negative {
+		coefficient.Neg(coefficient)
+	}
+
+	scale := int64(len(fraction))
+
+	if hasExponent {
+		shift, err := strconv.ParseInt(exponent, 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("%w: %q", errDecimalSyntax, text)
+		}
+
+		scale -= shift
+	}
+
+	if scale < -maxDecimalScale || scale > maxDecimalScale {
+		return Decimal{}, fmt.Errorf("%w: %q", errDecimalRange, text)
+	}
+
+	return Decimal{coefficient: coefficient, scale: int32(scale)}.normalize(), nil
+}
+
+// normalize keeps the scale positive, e.g. 15e2 is stored as 1500.
+func (d Decimal) normalize() Decimal {
+	if d.scale >= 0 {
+		return d
+	}
+
+	return Decimal{coefficient: new(big.Int).Mul(d.int(), pow10(-d.scale)), scale: 0}
+}
+
+func (d Decimal) int() *big.Int {
+	if d.coefficient == nil {
+		return new(big.Int)
+	}
+
+	return d.coefficient
+}
+
+func pow10(exponent int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil) //nolint:mnd // base 10.
+}
+
+// rescale returns the coefficient at the larger scale.
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale <= d.scale {
+		return d.int()
+	}
+
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+// aligned returns the coefficients of both numbers at the same scale.
+func (d Decimal) aligned(other Decimal) (*big.Int, *big.Int, int32) {
+	scale := max(d.scale, other.scale)
+
+	return d.rescale(scale), other.rescale(scale), scale
+}
+
+// Add returns d + other.
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, scale := d.aligned(other)
+
+	return Decimal{coefficient: new(big.Int).Add(a, b), scale: scale}
+}
+
+// Sub returns d - other.
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, scale := d.aligned(other)
+
+	return Decimal{coefficient: new(big.Int).Sub(a, b), scale: scale}
+}
+
+// Mul returns d * other.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{coefficient: new(big.Int).Mul(d.int(), other.int()), scale: d.scale + other.scale}
+}
+
+// Quo returns d / other, rounded half away from zero to the number of digits after the point.
+func (d Decimal) Quo(other Decimal, places int32) (Decimal, error) {
+	if other.IsZero() {
+		return Decimal{}, errDivisionByZero
+	}
+
+	// d / other = (a * 10^shift) / b * 10^-places, with a and b the coefficients
+	numerator := new(big.Int).Set(d.int())
+	denominator := new(big.Int).Set(other.int())
+
+	shift := places - d.scale + other.scale
+	if shift >= 0 {
+		numerator.Mul(numerator, pow10(shift))
+	} else {
+		denominator.Mul(denominator, pow10(-shift))
+	}
+
+	return Decimal{coefficient: roundQuo(numerator, denominator), scale: places}.normalize(), nil
+}
+
+// Round returns d rounded half away from zero to the number of digits after the point.
+func (d Decimal) Round(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+
+	return Decimal{coefficient: roundQuo(d.int(), pow10(d.scale-places)), scale: places}.normalize()
+}
+
+// roundQuo divides, rounding half away from zero.
+func roundQuo(numerator, denominator *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+
+	twice := new(big.Int).Abs(remainder)
+	twice.Lsh(twice, 1)
+
+	if twice.CmpAbs(denominator) >= 0 {
+		if numerator.Sign()*denominator.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return quotient
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{coefficient: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	return Decimal{coefficient: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// Cmp returns -1, 0 or +1 when d is lower, equal or greater than other.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := d.aligned(other)
+
+	return a.Cmp(b)
+}
+
+// Equal reports whether d and other are the same number, whatever their number of digits, e.g. 1.50 and 1.5.
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// Sign returns -1, 0 or +1 for a negative, zero or positive number.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Scale returns the number of digits after the point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Float64 returns the nearest float, for display or statistics, not for further arithmetic.
+func (d Decimal) Float64() float64 {
+	value, _ := new(big.Rat).SetFrac(d.int(), pow10(d.scale)).Float64()
+
+	return value
+}
+
+// String returns the number with its digits after the point, e.g. "150.50".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+
+	if d.scale == 0 {
+		return sign + digits
+	}
+
+	if padding := int(d.scale) + 1 - len(digits); padding > 0 {
+		digits = strings.Repeat("0", padding) + digits
+	}
+
+	point := len(digits) - int(d.scale)
+
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// MarshalJSON implements json.Marshaler, as a json number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, from a json number, a string holding one, or null for 0.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+
+		return nil
+	}
+
+	text := string(data)
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+
+	decimal, err := ParseDecimal(text)
+	if err != nil {
+		return err
+	}
+
+	*d = decimal
+
+	return nil
+}
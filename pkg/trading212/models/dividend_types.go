@@ -0,0 +1,133 @@
+package models
+
+// DividendType is the kind of a dividend, a payment of the instrument or of its tax treatment.
+type DividendType string
+
+// Dividend types, the manufactured payments are paid in lieu of a dividend on lent shares.
+const ( //nolint:lll // the names mirror the API values.
+	DividendTypeOrdinary                                       DividendType = "ORDINARY"
+	DividendTypeBonus                                          DividendType = "BONUS"
+	DividendTypePropertyIncome                                 DividendType = "PROPERTY_INCOME"
+	DividendTypeReturnOfCapitalNonUS                           DividendType = "RETURN_OF_CAPITAL_NON_US"
+	DividendTypeDemerger                                       DividendType = "DEMERGER"
+	DividendTypeInterest                                       DividendType = "INTEREST"
+	DividendTypeCapitalGainsDistributionNonUS                  DividendType = "CAPITAL_GAINS_DISTRIBUTION_NON_US"
+	DividendTypeInterimLiquidation                             DividendType = "INTERIM_LIQUIDATION"
+	DividendTypeInterestPaidByUSObligors                       DividendType = "INTEREST_PAID_BY_US_OBLIGORS"
+	DividendTypeInterestPaidByForeignCorporations              DividendType = "INTEREST_PAID_BY_FOREIGN_CORPORATIONS"
+	DividendTypeDividendsPaidByUSCorporations                  DividendType = "DIVIDENDS_PAID_BY_US_CORPORATIONS"
+	DividendTypeDividendsPaidByForeignCorporations             DividendType = "DIVIDENDS_PAID_BY_FOREIGN_CORPORATIONS"
+	DividendTypeCapitalGains                                   DividendType = "CAPITAL_GAINS"
+	DividendTypeRealPropertyIncomeAndNaturalResourcesRoyalties DividendType = "REAL_PROPERTY_INCOME_AND_NATURAL_RESOURCES_ROYALTIES"
+	DividendTypeOtherIncome                                    DividendType = "OTHER_INCOME"
+	DividendTypeQualifiedInvestmentEntity                      DividendType = "QUALIFIED_INVESTMENT_ENTITY"
+	DividendTypeTrustDistribution                              DividendType = "TRUST_DISTRIBUTION"
+	DividendTypePubliclyTradedPartnershipDistribution          DividendType = "PUBLICLY_TRADED_PARTNERSHIP_DISTRIBUTION"
+	DividendTypeCapitalGainsDistribution                       DividendType = "CAPITAL_GAINS_DISTRIBUTION"
+	DividendTypeReturnOfCapital                                DividendType = "RETURN_OF_CAPITAL"
+	DividendTypeOtherDividendEquivalent                        DividendType = "OTHER_DIVIDEND_EQUIVALENT"
+	DividendTypeTaxEvent1446fForPubliclyTradedSecurities       DividendType = "TAX_EVENT_1446F_FOR_PUBLICLY_TRADED_SECURITIES"
+	DividendTypePtpUncharacterisedIncome                       DividendType = "PTP_UNCHARACTERISED_INCOME"
+	DividendTypeMultiple1042sTaxComponents                     DividendType = "MULTIPLE_1042S_TAX_COMPONENTS"
+	DividendTypeDividend                                       DividendType = "DIVIDEND"
+	DividendTypeShortTermCapitalGains                          DividendType = "SHORT_TERM_CAPITAL_GAINS"
+	DividendTypeLongTermCapitalGains                           DividendType = "LONG_TERM_CAPITAL_GAINS"
+	DividendTypePropertyIncomeDistribution                     DividendType = "PROPERTY_INCOME_DISTRIBUTION"
+	DividendTypeTaxExempted                                    DividendType = "TAX_EXEMPTED"
+
+	DividendTypeOrdinaryManufacturedPayment                                       DividendType = "ORDINARY_MANUFACTURED_PAYMENT"
+	DividendTypeBonusManufacturedPayment                                          DividendType = "BONUS_MANUFACTURED_PAYMENT"
+	DividendTypePropertyIncomeManufacturedPayment                                 DividendType = "PROPERTY_INCOME_MANUFACTURED_PAYMENT"
+	DividendTypeReturnOfCapitalNonUSManufacturedPayment                           DividendType = "RETURN_OF_CAPITAL_NON_US_MANUFACTURED_PAYMENT"
+	DividendTypeDemergerManufacturedPayment                                       DividendType = "DEMERGER_MANUFACTURED_PAYMENT"
+	DividendTypeInterestManufacturedPayment                                       DividendType = "INTEREST_MANUFACTURED_PAYMENT"
+	DividendTypeCapitalGainsDistributionNonUSManufacturedPayment                  DividendType = "CAPITAL_GAINS_DISTRIBUTION_NON_US_MANUFACTURED_PAYMENT"
+	DividendTypeInterimLiquidationManufacturedPayment                             DividendType = "INTERIM_LIQUIDATION_MANUFACTURED_PAYMENT"
+	DividendTypeInterestPaidByUSObligorsManufacturedPayment                       DividendType = "INTEREST_PAID_BY_US_OBLIGORS_MANUFACTURED_PAYMENT"
+	DividendTypeInterestPaidByForeignCorporationsManufacturedPayment              DividendType = "INTEREST_PAID_BY_FOREIGN_CORPORATIONS_MANUFACTURED_PAYMENT"
+	DividendTypeDividendsPaidByUSCorporationsManufacturedPayment                  DividendType = "DIVIDENDS_PAID_BY_US_CORPORATIONS_MANUFACTURED_PAYMENT"
+	DividendTypeDividendsPaidByForeignCorporationsManufacturedPayment             DividendType = "DIVIDENDS_PAID_BY_FOREIGN_CORPORATIONS_MANUFACTURED_PAYMENT"
+	DividendTypeCapitalGainsManufacturedPayment                                   DividendType = "CAPITAL_GAINS_MANUFACTURED_PAYMENT"
+	DividendTypeRealPropertyIncomeAndNaturalResourcesRoyaltiesManufacturedPayment DividendType = "REAL_PROPERTY_INCOME_AND_NATURAL_RESOURCES_ROYALTIES_MANUFACTURED_PAYMENT"
+	DividendTypeOtherIncomeManufacturedPayment                                    DividendType = "OTHER_INCOME_MANUFACTURED_PAYMENT"
+	DividendTypeQualifiedInvestmentEntityManufacturedPayment                      DividendType = "QUALIFIED_INVESTMENT_ENTITY_MANUFACTURED_PAYMENT"
+	DividendTypeTrustDistributionManufacturedPayment                              DividendType = "TRUST_DISTRIBUTION_MANUFACTURED_PAYMENT"
+	DividendTypePubliclyTradedPartnershipDistributionManufacturedPayment          DividendType = "PUBLICLY_TRADED_PARTNERSHIP_DISTRIBUTION_MANUFACTURED_PAYMENT"
+	DividendTypeCapitalGainsDistributionManufacturedPayment                       DividendType = "CAPITAL_GAINS_DISTRIBUTION_MANUFACTURED_PAYMENT"
+	DividendTypeReturnOfCapitalManufacturedPayment                                DividendType = "RETURN_OF_CAPITAL_MANUFACTURED_PAYMENT"
+	DividendTypeOtherDividendEquivalentManufacturedPayment                        DividendType = "OTHER_DIVIDEND_EQUIVALENT_MANUFACTURED_PAYMENT"
+	DividendTypeTaxEvent1446fForPubliclyTradedSecuritiesManufacturedPayment       DividendType = "TAX_EVENT_1446F_FOR_PUBLICLY_TRADED_SECURITIES_MANUFACTURED_PAYMENT"
+	DividendTypePtpUncharacterisedIncomeManufacturedPayment                       DividendType = "PTP_UNCHARACTERISED_INCOME_MANUFACTURED_PAYMENT"
+	DividendTypeMultiple1042sTaxComponentsManufacturedPayment                     DividendType = "MULTIPLE_1042S_TAX_COMPONENTS_MANUFACTURED_PAYMENT"
+	DividendTypeDividendManufacturedPayment                                       DividendType = "DIVIDEND_MANUFACTURED_PAYMENT"
+	DividendTypeShortTermCapitalGainsManufacturedPayment                          DividendType = "SHORT_TERM_CAPITAL_GAINS_MANUFACTURED_PAYMENT"
+	DividendTypeLongTermCapitalGainsManufacturedPayment                           DividendType = "LONG_TERM_CAPITAL_GAINS_MANUFACTURED_PAYMENT"
+	DividendTypePropertyIncomeDistributionManufacturedPayment                     DividendType = "PROPERTY_INCOME_DISTRIBUTION_MANUFACTURED_PAYMENT"
+	DividendTypeTaxExemptedManufacturedPayment                                    DividendType = "TAX_EXEMPTED_MANUFACTURED_PAYMENT"
+)
+
+var dividendTypes = newEnum(
+	DividendTypeOrdinary,
+	DividendTypeBonus,
+	DividendTypePropertyIncome,
+	DividendTypeReturnOfCapitalNonUS,
+	DividendTypeDemerger,
+	DividendTypeInterest,
+	DividendTypeCapitalGainsDistributionNonUS,
+	DividendTypeInterimLiquidation,
+	DividendTypeOrdinaryManufacturedPayment,
+	DividendTypeBonusManufacturedPayment,
+	DividendTypePropertyIncomeManufacturedPayment,
+	DividendTypeReturnOfCapitalNonUSManufacturedPayment,
+	DividendTypeDemergerManufacturedPayment,
+	DividendTypeInterestManufacturedPayment,
+	DividendTypeCapitalGainsDistributionNonUSManufacturedPayment,
+	DividendTypeInterimLiquidationManufacturedPayment,
+	DividendTypeInterestPaidByUSObligors,
+	DividendTypeInterestPaidByForeignCorporations,
+	DividendTypeDividendsPaidByUSCorporations,
+	DividendTypeDividendsPaidByForeignCorporations,
+	DividendTypeCapitalGains,
+	DividendTypeRealPropertyIncomeAndNaturalResourcesRoyalties,
+	DividendTypeOtherIncome,
+	DividendTypeQualifiedInvestmentEntity,
+	DividendTypeTrustDistribution,
+	DividendTypePubliclyTradedPartnershipDistribution,
+	DividendTypeCapitalGainsDistribution,
+	DividendTypeReturnOfCapital,
+	DividendTypeOtherDividendEquivalent,
+	DividendTypeTaxEvent1446fForPubliclyTradedSecurities,
+	DividendTypePtpUncharacterisedIncome,
+	DividendTypeMultiple1042sTaxComponents,
+	DividendTypeDividend,
+	DividendTypeShortTermCapitalGains,
+	DividendTypeLongTermCapitalGains,
+	DividendTypePropertyIncomeDistribution,
+	DividendTypeTaxExempted,
+	DividendTypeInterestPaidByUSObligorsManufacturedPayment,
+	DividendTypeInterestPaidByForeignCorporationsManufacturedPayment,
+	DividendTypeDividendsPaidByUSCorporationsManufacturedPayment,
+	DividendTypeDividendsPaidByForeignCorporationsManufacturedPayment,
+	DividendTypeCapitalGainsManufacturedPayment,
+	DividendTypeRealPropertyIncomeAndNaturalResourcesRoyaltiesManufacturedPayment,
+	DividendTypeOtherIncomeManufacturedPayment,
+	DividendTypeQualifiedInvestmentEntityManufacturedPayment,
+	DividendTypeTrustDistributionManufacturedPayment,
+	DividendTypePubliclyTradedPartnershipDistributionManufacturedPayment,
+	DividendTypeCapitalGainsDistributionManufacturedPayment,
+	DividendTypeReturnOfCapitalManufacturedPayment,
+	DividendTypeOtherDividendEquivalentManufacturedPayment,
+	DividendTypeTaxEvent1446fForPubliclyTradedSecuritiesManufacturedPayment,
+	DividendTypePtpUncharacterisedIncomeManufacturedPayment,
+	DividendTypeMultiple1042sTaxComponentsManufacturedPayment,
+	DividendTypeDividendManufacturedPayment,
+	DividendTypeShortTermCapitalGainsManufacturedPayment,
+	DividendTypeLongTermCapitalGainsManufacturedPayment,
+	DividendTypePropertyIncomeDistributionManufacturedPayment,
+	DividendTypeTaxExemptedManufacturedPayment,
+)
+
+// IsKnown reports whether the type is one of the constants, the API may add others.
+func (t DividendType) IsKnown() bool {
+	return dividendTypes.has(t)
+}
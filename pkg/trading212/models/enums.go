@@ -0,0 +1,239 @@
+package models
+
+// The enums are strings, so the values added later by the API are decoded as is.
+// Their IsKnown method reports whether the value is one of the constants, the client
+// reporting the other ones like the unknown fields.
+
+// enum is the set of the known values of an enum.
+type enum[T ~string] map[T]struct{}
+
+func newEnum[T ~string](values ...T) enum[T] {
+	set := make(enum[T], len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+
+	return set
+}
+
+func (set enum[T]) has(value T) bool {
+	_, ok := set[value]
+
+	return ok
+}
+
+// OrderStatus is the state of an order in its lifecycle.
+type OrderStatus string
+
+// Order statuses.
+const (
+	OrderStatusLocal           OrderStatus = "LOCAL"
+	OrderStatusUnconfirmed     OrderStatus = "UNCONFIRMED"
+	OrderStatusConfirmed       OrderStatus = "CONFIRMED"
+	OrderStatusNew             OrderStatus = "NEW"
+	OrderStatusCancelling      OrderStatus = "CANCELLING"
+	OrderStatusCancelled       OrderStatus = "CANCELLED"
+	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	OrderStatusFilled          OrderStatus = "FILLED"
+	OrderStatusRejected        OrderStatus = "REJECTED"
+	OrderStatusReplacing       OrderStatus = "REPLACING"
+	OrderStatusReplaced        OrderStatus = "REPLACED"
+)
+
+var orderStatuses = newEnum(
+	OrderStatusLocal, OrderStatusUnconfirmed, OrderStatusConfirmed, OrderStatusNew, OrderStatusCancelling,
+	OrderStatusCancelled, OrderStatusPartiallyFilled, OrderStatusFilled, OrderStatusRejected,
+	OrderStatusReplacing, OrderStatusReplaced,
+)
+
+var terminalOrderStatuses = newEnum(OrderStatusFilled, OrderStatusCancelled, OrderStatusRejected, OrderStatusReplaced)
+
+// IsKnown reports whether the status is one of the constants, the API may add others.
+func (s OrderStatus) IsKnown() bool {
+	return orderStatuses.has(s)
+}
+
+// IsTerminal reports whether the order is done: filled, cancelled, rejected or replaced by another one.
+func (s OrderStatus) IsTerminal() bool {
+	return terminalOrderStatuses.has(s)
+}
+
+// IsActive reports whether the order may still fill, or change of status.
+// An unknown status is neither active nor terminal.
+func (s OrderStatus) IsActive() bool {
+	return s.IsKnown() && !s.IsTerminal()
+}
+
+// OrderSide is whether an order buys or sells.
+type OrderSide string
+
+// Order sides.
+const (
+	OrderSideBuy  OrderSide = "BUY"
+	OrderSideSell OrderSide = "SELL"
+)
+
+var orderSides = newEnum(OrderSideBuy, OrderSideSell)
+
+// IsKnown reports whether the side is one of the constants, the API may add others.
+func (s OrderSide) IsKnown() bool {
+	return orderSides.has(s)
+}
+
+// OrderType is the kind of an order.
+type OrderType string
+
+// Order types.
+const (
+	OrderTypeLimit     OrderType = "LIMIT"
+	OrderTypeStop      OrderType = "STOP"
+	OrderTypeMarket    OrderType = "MARKET"
+	OrderTypeStopLimit OrderType = "STOP_LIMIT"
+)
+
+var orderTypes = newEnum(OrderTypeLimit, OrderTypeStop, OrderTypeMarket, OrderTypeStopLimit)
+
+// IsKnown reports whether the type is one of the constants, the API may add others.
+func (t OrderType) IsKnown() bool {
+	return orderTypes.has(t)
+}
+
+// TimeInForce is how long a limit or stop-limit order remains active.
+type TimeInForce string
+
+// Time in force of the limit and stop-limit orders.
+const (
+	// TimeInForceDay orders expire if not executed by midnight in the time zone of the exchange.
+	TimeInForceDay TimeInForce = "DAY"
+	// TimeInForceGoodTillCancel orders remain active until filled or cancelled.
+	TimeInForceGoodTillCancel TimeInForce = "GOOD_TILL_CANCEL"
+)
+
+var timesInForce = newEnum(TimeInForceDay, TimeInForceGoodTillCancel)
+
+// IsKnown reports whether the time in force is one of the constants, the API may add others.
+func (t TimeInForce) IsKnown() bool {
+	return timesInForce.has(t)
+}
+
+// OrderStrategy is whether an order is placed by quantity or by value.
+type OrderStrategy string
+
+// Order strategies, the API only places orders by quantity.
+const (
+	OrderStrategyQuantity OrderStrategy = "QUANTITY"
+	OrderStrategyValue    OrderStrategy = "VALUE"
+)
+
+var orderStrategies = newEnum(OrderStrategyQuantity, OrderStrategyValue)
+
+// IsKnown reports whether the strategy is one of the constants, the API may add others.
+func (s OrderStrategy) IsKnown() bool {
+	return orderStrategies.has(s)
+}
+
+// InitiatedFrom is where an order was placed from.
+type InitiatedFrom string
+
+// Origins of the orders.
+const (
+	InitiatedFromAPI        InitiatedFrom = "API"
+	InitiatedFromIOS        InitiatedFrom = "IOS"
+	InitiatedFromAndroid    InitiatedFrom = "ANDROID"
+	InitiatedFromWeb        InitiatedFrom = "WEB"
+	InitiatedFromSystem     InitiatedFrom = "SYSTEM"
+	InitiatedFromAutoinvest InitiatedFrom = "AUTOINVEST"
+)
+
+var initiatedFroms = newEnum(
+	InitiatedFromAPI, InitiatedFromIOS, InitiatedFromAndroid, InitiatedFromWeb, InitiatedFromSystem,
+	InitiatedFromAutoinvest,
+)
+
+// IsKnown reports whether the origin is one of the constants, the API may add others.
+func (i InitiatedFrom) IsKnown() bool {
+	return initiatedFroms.has(i)
+}
+
+// InstrumentType is the kind of an instrument.
+type InstrumentType string
+
+// Instrument types.
+const (
+	InstrumentTypeCryptocurrency InstrumentType = "CRYPTOCURRENCY"
+	InstrumentTypeETF            InstrumentType = "ETF"
+	InstrumentTypeForex          InstrumentType = "FOREX"
+	InstrumentTypeFutures        InstrumentType = "FUTURES"
+	InstrumentTypeIndex          InstrumentType = "INDEX"
+	InstrumentTypeStock          InstrumentType = "STOCK"
+	InstrumentTypeWarrant        InstrumentType = "WARRANT"
+	InstrumentTypeCrypto         InstrumentType = "CRYPTO"
+	InstrumentTypeCVR            InstrumentType = "CVR"
+	InstrumentTypeCorpact        InstrumentType = "CORPACT"
+)
+
+var instrumentTypes = newEnum(
+	InstrumentTypeCryptocurrency, InstrumentTypeETF, InstrumentTypeForex, InstrumentTypeFutures,
+	InstrumentTypeIndex, InstrumentTypeStock, InstrumentTypeWarrant, InstrumentTypeCrypto, InstrumentTypeCVR,
+	InstrumentTypeCorpact,
+)
+
+// IsKnown reports whether the type is one of the constants, the API may add others.
+func (t InstrumentType) IsKnown() bool {
+	return instrumentTypes.has(t)
+}
+
+// TimeEventType is the kind of an event of an exchange working schedule.
+type TimeEventType string
+
+// Time events of the exchanges.
+const (
+	TimeEventOpen            TimeEventType = "OPEN"
+	TimeEventClose           TimeEventType = "CLOSE"
+	TimeEventBreakStart      TimeEventType = "BREAK_START"
+	TimeEventBreakEnd        TimeEventType = "BREAK_END"
+	TimeEventPreMarketOpen   TimeEventType = "PRE_MARKET_OPEN"
+	TimeEventAfterHoursOpen  TimeEventType = "AFTER_HOURS_OPEN"
+	TimeEventAfterHoursClose TimeEventType = "AFTER_HOURS_CLOSE"
+	TimeEventOvernightOpen   TimeEventType = "OVERNIGHT_OPEN"
+)
+
+var timeEventTypes = newEnum(
+	TimeEventOpen, TimeEventClose, TimeEventBreakStart, TimeEventBreakEnd, TimeEventPreMarketOpen,
+	TimeEventAfterHoursOpen, TimeEventAfterHoursClose, TimeEventOvernightOpen,
+)
+
+// IsKnown reports whether the type is one of the constants, the API may add others.
+func (t TimeEventType) IsKnown() bool {
+	return timeEventTypes.has(t)
+}
+
+// ReportStatus is the progress of the generation of a report.
+type ReportStatus string
+
+// Report statuses.
+const (
+	ReportStatusQueued     ReportStatus = "Queued"
+	ReportStatusProcessing ReportStatus = "Processing"
+	ReportStatusRunning    ReportStatus = "Running"
+	ReportStatusCanceled   ReportStatus = "Canceled"
+	ReportStatusFailed     ReportStatus = "Failed"
+	ReportStatusFinished   ReportStatus = "Finished"
+)
+
+var reportStatuses = newEnum(
+	ReportStatusQueued, ReportStatusProcessing, ReportStatusRunning, ReportStatusCanceled, ReportStatusFailed,
+	ReportStatusFinished,
+)
+
+var terminalReportStatuses = newEnum(ReportStatusFinished, ReportStatusFailed, ReportStatusCanceled)
+
+// IsKnown reports whether the status is one of the constants, the API may add others.
+func (s ReportStatus) IsKnown() bool {
+	return reportStatuses.has(s)
+}
+
+// IsTerminal reports whether the report is done: finished, failed or canceled.
+func (s ReportStatus) IsTerminal() bool {
+	return terminalReportStatuses.has(s)
+}
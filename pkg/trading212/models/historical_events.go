@@ -5,13 +5,13 @@ import "time"
 // Dividend response type.
 type Dividend struct {
 	// Amount, in account's primary currency.
-	Amount int `json:"amount"`
+	Amount Money `json:"amount"`
 	// AmountInEuro.
-	AmountInEuro int `json:"amountInEuro"`
+	AmountInEuro Money `json:"amountInEuro"`
 	// Currency, the account's primary currency.
 	Currency string `json:"currency"`
 	// GrossAmountPerShare, in instrument currency.
-	GrossAmountPerShare int `json:"grossAmountPerShare"`
+	GrossAmountPerShare Money `json:"grossAmountPerShare"`
 	// Instrument information as given by /instruments endpoint.
 	Instrument struct {
 		// Currency, instrument currency in ISO 4217 format.
@@ -21,47 +21,20 @@ type Dividend struct {
 		// Name or the instrument.
 		Name string `json:"name"`
 		// Ticker, unique instrument identifier.
-		Ticker string `json:"ticker"`
+		Ticker Ticker `json:"ticker"`
 	} `json:"instrument"`
 	// PaidOn time.
 	PaidOn time.Time `json:"paidOn"`
 	// Quantity.
-	Quantity int `json:"quantity"`
+	Quantity Quantity `json:"quantity"`
 	// Reference.
 	Reference string `json:"reference"`
 	// Ticker.
-	Ticker string `json:"ticker"`
+	Ticker Ticker `json:"ticker"`
 	// TickerCurrency.
 	TickerCurrency string `json:"tickerCurrency"`
-	// Type, enum "ORDINARY", "BONUS", "PROPERTY_INCOME", "RETURN_OF_CAPITAL_NON_US", "DEMERGER", "INTEREST",
-	// "CAPITAL_GAINS_DISTRIBUTION_NON_US", "INTERIM_LIQUIDATION", "ORDINARY_MANUFACTURED_PAYMENT",
-	// "BONUS_MANUFACTURED_PAYMENT", "PROPERTY_INCOME_MANUFACTURED_PAYMENT",
-	// "RETURN_OF_CAPITAL_NON_US_MANUFACTURED_PAYMENT", "DEMERGER_MANUFACTURED_PAYMENT",
-	// "INTEREST_MANUFACTURED_PAYMENT", "CAPITAL_GAINS_DISTRIBUTION_NON_US_MANUFACTURED_PAYMENT",
-	// "INTERIM_LIQUIDATION_MANUFACTURED_PAYMENT", "INTEREST_PAID_BY_US_OBLIGORS",
-	// "INTEREST_PAID_BY_FOREIGN_CORPORATIONS", "DIVIDENDS_PAID_BY_US_CORPORATIONS",
-	// "DIVIDENDS_PAID_BY_FOREIGN_CORPORATIONS", "CAPITAL_GAINS",
-	// "REAL_PROPERTY_INCOME_AND_NATURAL_RESOURCES_ROYALTIES", "OTHER_INCOME", "QUALIFIED_INVESTMENT_ENTITY",
-	// "TRUST_DISTRIBUTION", "PUBLICLY_TRADED_PARTNERSHIP_DISTRIBUTION", "CAPITAL_GAINS_DISTRIBUTION",
-	// "RETURN_OF_CAPITAL", "OTHER_DIVIDEND_EQUIVALENT", "TAX_EVENT_1446F_FOR_PUBLICLY_TRADED_SECURITIES",
-	// "PTP_UNCHARACTERISED_INCOME", "MULTIPLE_1042S_TAX_COMPONENTS", "DIVIDEND", "SHORT_TERM_CAPITAL_GAINS",
-	// "LONG_TERM_CAPITAL_GAINS", "PROPERTY_INCOME_DISTRIBUTION", "TAX_EXEMPTED",
-	// "INTEREST_PAID_BY_US_OBLIGORS_MANUFACTURED_PAYMENT",
-	// "INTEREST_PAID_BY_FOREIGN_CORPORATIONS_MANUFACTURED_PAYMENT",
-	// "DIVIDENDS_PAID_BY_US_CORPORATIONS_MANUFACTURED_PAYMENT",
-	// "DIVIDENDS_PAID_BY_FOREIGN_CORPORATIONS_MANUFACTURED_PAYMENT",
-	// "CAPITAL_GAINS_MANUFACTURED_PAYMENT",
-	// "REAL_PROPERTY_INCOME_AND_NATURAL_RESOURCES_ROYALTIES_MANUFACTURED_PAYMENT", "OTHER_INCOME_MANUFACTURED_PAYMENT",
-	// "QUALIFIED_INVESTMENT_ENTITY_MANUFACTURED_PAYMENT", "TRUST_DISTRIBUTION_MANUFACTURED_PAYMENT",
-	// "PUBLICLY_TRADED_PARTNERSHIP_DISTRIBUTION_MANUFACTURED_PAYMENT",
-	// "CAPITAL_GAINS_DISTRIBUTION_MANUFACTURED_PAYMENT", "RETURN_OF_CAPITAL_MANUFACTURED_PAYMENT",
-	// "OTHER_DIVIDEND_EQUIVALENT_MANUFACTURED_PAYMENT",
-	// "TAX_EVENT_1446F_FOR_PUBLICLY_TRADED_SECURITIES_MANUFACTURED_PAYMENT",
-	// "PTP_UNCHARACTERISED_INCOME_MANUFACTURED_PAYMENT", "MULTIPLE_1042S_TAX_COMPONENTS_MANUFACTURED_PAYMENT",
-	// "DIVIDEND_MANUFACTURED_PAYMENT", "SHORT_TERM_CAPITAL_GAINS_MANUFACTURED_PAYMENT",
-	// "LONG_TERM_CAPITAL_GAINS_MANUFACTURED_PAYMENT", "PROPERTY_INCOME_DISTRIBUTION_MANUFACTURED_PAYMENT",
-	// "TAX_EXEMPTED_MANUFACTURED_PAYMENT".
-	Type string `json:"type"`
+	// Type.
+	Type DividendType `json:"type"`
 }
 
 // ReportID response type.
@@ -100,7 +73,7 @@ type Report struct {
 	// DownloadLink.
 	DownloadLink string `json:"downloadLink"`
 	// Status.
-	Status string `json:"status"`
+	Status ReportStatus `json:"status"`
 }
 
 // ReportRequest request type.
@@ -120,9 +93,9 @@ type OrderFill struct {
 		// ID.
 		ID int `json:"id"`
 		// Price.
-		Price int `json:"price"`
+		Price Money `json:"price"`
 		// Quantity.
-		Quantity int `json:"quantity"`
+		Quantity Quantity `json:"quantity"`
 		// TradingMethod.
 		TradingMethod string `json:"tradingMethod"`
 		// Type.
@@ -132,11 +105,11 @@ type OrderFill struct {
 			// Currency.
 			Currency string `json:"currency"`
 			// FxRate.
-			FxRate int `json:"fxRate"`
+			FxRate Decimal `json:"fxRate"`
 			// NetValue.
-			NetValue int `json:"netValue"`
+			NetValue Money `json:"netValue"`
 			// RealisedProfitLoss.
-			RealisedProfitLoss int `json:"realisedProfitLoss"`
+			RealisedProfitLoss Money `json:"realisedProfitLoss"`
 			// Taxes.
 			Taxes []struct {
 				// ChargedAt.
@@ -146,7 +119,7 @@ type OrderFill struct {
 				// Name.
 				Name string `json:"name"`
 				// Quantity.
-				Quantity int `json:"quantity"`
+				Quantity Money `json:"quantity"`
 			} `json:"taxes"`
 		} `json:"walletImpact"`
 	} `json:"fill"`
@@ -155,7 +128,7 @@ type OrderFill struct {
 // Transaction response type.
 type Transaction struct {
 	// Amount.
-	Amount int `json:"amount"`
+	Amount Money `json:"amount"`
 	// Currency.
 	Currency string `json:"currency"`
 	// DateTime.
@@ -165,3 +138,36 @@ type Transaction struct {
 	// Type.
 	Type string `json:"type"`
 }
+
+// AttachCurrency sets the account currency to the amounts, and the instrument currency to the
+// gross amount per share, done by the client once decoded.
+func (dividend *Dividend) AttachCurrency() {
+	withCurrency(dividend.Currency, &dividend.Amount)
+	withCurrency("EUR", &dividend.AmountInEuro)
+
+	currency := dividend.Instrument.Currency
+	if currency == "" {
+		currency = dividend.TickerCurrency
+	}
+
+	withCurrency(currency, &dividend.GrossAmountPerShare)
+}
+
+// AttachCurrency sets the currencies of the order and of the fill, done by the client once decoded.
+func (fill *OrderFill) AttachCurrency() {
+	fill.Order.AttachCurrency()
+
+	withCurrency(fill.Instrument.Currency, &fill.Fill.Price)
+	withCurrency(fill.Fill.WalletImpact.Currency, &fill.Fill.WalletImpact.NetValue,
+		&fill.Fill.WalletImpact.RealisedProfitLoss)
+
+	for index := range fill.Fill.WalletImpact.Taxes {
+		tax := &fill.Fill.WalletImpact.Taxes[index]
+		withCurrency(tax.Currency, &tax.Quantity)
+	}
+}
+
+// AttachCurrency sets the currency to the amount, done by the client once decoded.
+func (transaction *Transaction) AttachCurrency() {
+	withCurrency(transaction.Currency, &transaction.Amount)
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Validate the report request.
+func (req ReportRequest) Validate() error {
+	const request = "ReportRequest"
+
+	var errs []error
+
+	if req.TimeFrom.IsZero() || req.TimeTo.IsZero() || !req.TimeTo.After(req.TimeFrom) {
+		errs = append(errs, requestError(request, "timeTo", errTimeRange))
+	}
+
+	data := req.DataIncluded
+	if !data.IncludeDividends && !data.IncludeInterest && !data.IncludeOrders && !data.IncludeTransactions {
+		errs = append(errs, requestError(request, "dataIncluded", errNoData))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ReportRequestBuilder builds a ReportRequest, see NewReportRequest.
+type ReportRequestBuilder struct {
+	req ReportRequest
+}
+
+// NewReportRequest starts a report request, e.g.
+//
+//	req, err := NewReportRequest().From(start).To(end).IncludeOrders().IncludeDividends().Build()
+func NewReportRequest() *ReportRequestBuilder {
+	return &ReportRequestBuilder{req: ReportRequest{}}
+}
+
+// From the time, included.
+func (b *ReportRequestBuilder) From(from time.Time) *ReportRequestBuilder {
+	b.req.TimeFrom = from
+
+	return b
+}
+
+// To the time.
+func (b *ReportRequestBuilder) To(to time.Time) *ReportRequestBuilder {
+	b.req.TimeTo = to
+
+	return b
+}
+
+// IncludeDividends in the report.
+func (b *ReportRequestBuilder) IncludeDividends() *ReportRequestBuilder {
+	b.req.DataIncluded.IncludeDividends = true
+
+	return b
+}
+
+// IncludeInterest in the report.
+func (b *ReportRequestBuilder) IncludeInterest() *ReportRequestBuilder {
+	b.req.DataIncluded.IncludeInterest = true
+
+	return b
+}
+
+// IncludeOrders in the report.
+func (b *ReportRequestBuilder) IncludeOrders() *ReportRequestBuilder {
+	b.req.DataIncluded.IncludeOrders = true
+
+	return b
+}
+
+// IncludeTransactions in the report.
+func (b *ReportRequestBuilder) IncludeTransactions() *ReportRequestBuilder {
+	b.req.DataIncluded.IncludeTransactions = true
+
+	return b
+}
+
+// IncludeAll the kinds of data in the report.
+func (b *ReportRequestBuilder) IncludeAll() *ReportRequestBuilder {
+	return b.IncludeDividends().IncludeInterest().IncludeOrders().IncludeTransactions()
+}
+
+// Build returns the request, only usable when the error is nil.
+// The error joins a *RequestError per invalid field.
+func (b *ReportRequestBuilder) Build() (ReportRequest, error) {
+	return b.req, b.req.Validate()
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// MaxHistoryLimit is the maximum number of items per page of the historical endpoints.
+const MaxHistoryLimit = 50
+
+var (
+	errHistoryLimit  = fmt.Errorf("limit should be between 0 and %d", MaxHistoryLimit)
+	errHistoryCursor = errors.New("cursor should not be negative")
+)
+
+// HistoryQuery request parameters of the historical orders and dividends.
+// Zero values are not sent.
+type HistoryQuery struct {
+	// Cursor, the identifier to start the page from.
+	Cursor int64
+	// Ticker, only return the items of this instrument.
+	Ticker Ticker
+	// Limit, the number of items per page, at most MaxHistoryLimit.
+	// Defaults to the page size of the client.
+	Limit int
+}
+
+// Validate the query parameters.
+func (query HistoryQuery) Validate() error {
+	var errs []error
+
+	if query.Cursor < 0 {
+		errs = append(errs, fmt.Errorf("%w: got %d", errHistoryCursor, query.Cursor))
+	}
+
+	if query.Ticker != "" {
+		errs = append(errs, query.Ticker.Validate())
+	}
+
+	errs = append(errs, validateLimit(query.Limit))
+
+	return errors.Join(errs...)
+}
+
+// Values encodes the query parameters.
+func (query HistoryQuery) Values() url.Values {
+	values := url.Values{}
+
+	if query.Cursor != 0 {
+		values.Set("cursor", strconv.FormatInt(query.Cursor, 10))
+	}
+
+	if query.Ticker != "" {
+		values.Set("ticker", string(query.Ticker))
+	}
+
+	if query.Limit != 0 {
+		values.Set("limit", strconv.Itoa(query.Limit))
+	}
+
+	return values
+}
+
+// TransactionsQuery request parameters of the transactions.
+// Zero values are not sent.
+type TransactionsQuery struct {
+	// Cursor, the opaque token to start the page from.
+	Cursor string
+	// Time, only return the transactions from this time.
+	Time time.Time
+	// Limit, the number of items per page, at most MaxHistoryLimit.
+	// Defaults to the page size of the client.
+	Limit int
+}
+
+// Validate the query parameters.
+func (query TransactionsQuery) Validate() error {
+	return validateLimit(query.Limit)
+}
+
+// Values encodes the query parameters.
+func (query TransactionsQuery) Values() url.Values {
+	values := url.Values{}
+
+	if query.Cursor != "" {
+		values.Set("cursor", query.Cursor)
+	}
+
+	if !query.Time.IsZero() {
+		values.Set("time", query.Time.UTC().Format(time.RFC3339))
+	}
+
+	if query.Limit != 0 {
+		values.Set("limit", strconv.Itoa(query.Limit))
+	}
+
+	return values
+}
+
+func validateLimit(limit int) error {
+	if limit < 0 || limit > MaxHistoryLimit {
+		return fmt.Errorf("%w: got %d", errHistoryLimit, limit)
+	}
+
+	return nil
+}
@@ -9,10 +9,8 @@ type ExchangeMetadata struct {
 	WorkingSchedules []struct {
 		ID         uint `json:"id"`
 		TimeEvents []struct {
-			Date time.Time `json:"date"`
-			//  Enum "OPEN", "CLOSE", "BREAK_START", "BREAK_END", "PRE_MARKET_OPEN",
-			//  "AFTER_HOURS_OPEN", "AFTER_HOURS_CLOSE", "OVERNIGHT_OPEN"
-			Type string `json:"type"`
+			Date time.Time     `json:"date"`
+			Type TimeEventType `json:"type"`
 		} `json:"timeEvents"`
 	} `json:"workingSchedules"`
 }
@@ -28,15 +26,15 @@ type Instrument struct {
 	// Isin.
 	Isin string `json:"isin"`
 	// MaxOpenQuantity.
-	MaxOpenQuantity float64 `json:"maxOpenQuantity"`
+	MaxOpenQuantity Quantity `json:"maxOpenQuantity"`
 	// Name.
 	Name string `json:"name"`
 	// ShortName.
 	ShortName string `json:"shortName"`
 	// Unique identifier.
-	Ticker string `json:"ticker"`
-	// Enum "CRYPTOCURRENCY", "ETF", "FOREX", "FUTURES", "INDEX", "STOCK", "WARRANT", "CRYPTO", "CVR", "CORPACT".
-	Type string `json:"type"`
+	Ticker Ticker `json:"ticker"`
+	// Type.
+	Type InstrumentType `json:"type"`
 	// Get items in the /exchanges endpoint.
 	WorkingScheduleID uint `json:"workingScheduleId"`
 }
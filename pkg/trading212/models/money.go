@@ -0,0 +1,115 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errCurrencyMismatch = errors.New("currencies do not match")
+
+// Money is an exact amount in a currency.
+// It is encoded to json as its amount only, the currency is attached from the sibling currency
+// field of the model once decoded, and is empty when the API does not tell it.
+type Money struct {
+	// Amount.
+	Amount Decimal
+	// Currency in ISO 4217 format.
+	Currency string
+}
+
+// NewMoney returns the amount in the currency.
+func NewMoney(amount Decimal, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// currency returns the currency of an operation, an empty currency taking the other one.
+func (m Money) currency(other Money) (string, error) {
+	switch {
+	case m.Currency == other.Currency || other.Currency == "":
+		return m.Currency, nil
+	case m.Currency == "":
+		return other.Currency, nil
+	default:
+		return "", fmt.Errorf("%w: %s and %s", errCurrencyMismatch, m.Currency, other.Currency)
+	}
+}
+
+// Add returns m + other, both should be in the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.currency(other)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: m.Amount.Add(other.Amount), Currency: currency}, nil
+}
+
+// Sub returns m - other, both should be in the same currency.
+func (m Money) Sub(other Money) (Money, error) {
+	currency, err := m.currency(other)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: m.Amount.Sub(other.Amount), Currency: currency}, nil
+}
+
+// Mul returns m * factor, e.g. a price times a quantity.
+func (m Money) Mul(factor Decimal) Money {
+	return Money{Amount: m.Amount.Mul(factor), Currency: m.Currency}
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{Amount: m.Amount.Neg(), Currency: m.Currency}
+}
+
+// Cmp compares m with other, both should be in the same currency.
+func (m Money) Cmp(other Money) (int, error) {
+	if _, err := m.currency(other); err != nil {
+		return 0, err
+	}
+
+	return m.Amount.Cmp(other.Amount), nil
+}
+
+// Sign returns -1, 0 or +1 for a negative, zero or positive amount.
+func (m Money) Sign() int {
+	return m.Amount.Sign()
+}
+
+// IsZero reports whether the amount is 0.
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// Float64 returns the nearest float of the amount, for display or statistics.
+func (m Money) Float64() float64 {
+	return m.Amount.Float64()
+}
+
+// String returns the amount followed by the currency, e.g. "150.50 USD".
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Amount.String()
+	}
+
+	return m.Amount.String() + " " + m.Currency
+}
+
+// MarshalJSON implements json.Marshaler, as the amount only.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return m.Amount.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, from the amount only, keeping the currency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	return m.Amount.UnmarshalJSON(data)
+}
+
+// withCurrency sets the currency of the amounts.
+func withCurrency(currency string, amounts ...*Money) {
+	for _, amount := range amounts {
+		amount.Currency = currency
+	}
+}
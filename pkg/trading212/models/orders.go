@@ -11,15 +11,14 @@ type Order struct {
 	// If true, the order is eligible for execution outside regular trading hours.
 	ExtendedHours bool `json:"extendedHours"`
 	// The number of shares that have been successfully executed. Applicable to quantity orders.
-	FilledQuantity float64 `json:"filledQuantity"`
+	FilledQuantity Quantity `json:"filledQuantity"`
 	// The total monetary value of the executed portion of the order. Applicable to orders placed by value.
 	// Note: Placing orders by value is not currently supported via the API.
-	FilledValue float64 `json:"filledValue"`
+	FilledValue Money `json:"filledValue"`
 	// A unique, system-generated identifier for the order.
 	ID uint `json:"id"`
 	// How the order was initiated.
-	// Enum: "API", "IOS", "ANDROID", "WEB", "SYSTEM", "AUTOINVEST".
-	InitiatedFrom string `json:"initiatedFrom"`
+	InitiatedFrom InitiatedFrom `json:"initiatedFrom"`
 	// Instrument information as given by /instruments endpoint.
 	Instrument struct {
 		// Instrument currency in ISO 4217 format.
@@ -29,55 +28,51 @@ type Order struct {
 		// Name of the instrument.
 		Name string `json:"name"`
 		// Unique instrument identifier. (Example: "AAPL_US_EQ")
-		Ticker string `json:"ticker"`
+		Ticker Ticker `json:"ticker"`
 	} `json:"instrument"`
-	// Applicable to LIMIT and STOP_LIMIT orders.
-	LimitPrice float64 `json:"limitPrice"`
+	// Applicable to LIMIT and STOP_LIMIT orders, in the instrument currency.
+	LimitPrice Money `json:"limitPrice"`
 	// The total number of shares requested. Applicable to quantity orders.
-	Quantity float64 `json:"quantity"`
+	Quantity Quantity `json:"quantity"`
 	// Indicates whether the order is BUY or SELL.
-	Side string `json:"side"`
+	Side OrderSide `json:"side"`
 	// The current state of the order in its lifecycle.
-	// Enum: "LOCAL", "UNCONFIRMED", "CONFIRMED", "NEW", "CANCELLING", "CANCELLED", "PARTIALLY_FILLED",
-	// "FILLED", "REJECTED", "REPLACING", "REPLACED".
-	Status string `json:"status"`
-	// Applicable to STOP and STOP_LIMIT orders.
-	StopPrice float64 `json:"stopPrice"`
+	Status OrderStatus `json:"status"`
+	// Applicable to STOP and STOP_LIMIT orders, in the instrument currency.
+	StopPrice Money `json:"stopPrice"`
 	// The strategy used to place the order, either by QUANTITY or VALUE.
 	// The API currently only supports placing orders by QUANTITY.
-	//  Enum: "QUANTITY", "VALUE".
-	Strategy string `json:"strategy"`
+	Strategy OrderStrategy `json:"strategy"`
 	// Unique instrument identifier. Get from the /instruments endpoint.
-	Ticker string `json:"ticker"`
+	Ticker Ticker `json:"ticker"`
 	// Specifies how long the order remains active:
 	//  - DAY: The order will automatically expire if not executed by midnight
 	//    in the time zone of the instrument's exchange.
 	//  - GOOD_TILL_CANCEL: The order remains active indefinitely until
 	//    it is either filled or explicitly cancelled by you.
-	// Enum: "DAY", "GOOD_TILL_CANCEL".
-	TimeInForce string `json:"timeInForce"`
-	// Enum: "LIMIT", "STOP", "MARKET", "STOP_LIMIT".
-	Type string `json:"type"`
+	TimeInForce TimeInForce `json:"timeInForce"`
+	// Type of the order.
+	Type OrderType `json:"type"`
 	// The total monetary value of the order. Applicable to value orders.
-	Value float64 `json:"value"`
+	Value Money `json:"value"`
 }
 
 type baseOrderRequest struct {
 	// Unique instrument identifier. Get from the /instruments endpoint
-	Ticker string `json:"ticker"`
+	Ticker Ticker `json:"ticker"`
 	// The total number of shares requested. Applicable to quantity orders.
-	Quantity float64 `json:"quantity"`
+	Quantity Quantity `json:"quantity"`
 }
 type baseLimitOrderRequest struct {
-	// LimitPrice.
-	LimitPrice float64 `json:"limitPrice"`
+	// LimitPrice, in the instrument currency.
+	LimitPrice Decimal `json:"limitPrice"`
 	// TimeInForce.
-	TimeInForce string `json:"timeInForce,omitempty"`
+	TimeInForce TimeInForce `json:"timeInForce,omitempty"`
 }
 
 type baseStopOrderRequest struct {
-	// StopPrice.
-	StopPrice float64 `json:"stopPrice"`
+	// StopPrice, in the instrument currency.
+	StopPrice Decimal `json:"stopPrice"`
 }
 
 // LimitOrderRequest response type.
@@ -98,6 +93,7 @@ type MarketOrderRequest struct {
 type StopLimitOrderRequest struct {
 	baseOrderRequest
 	baseLimitOrderRequest
+	baseStopOrderRequest
 }
 
 // StopOrderRequest response type.
@@ -105,3 +101,10 @@ type StopOrderRequest struct {
 	baseOrderRequest
 	baseStopOrderRequest
 }
+
+// AttachCurrency sets the order currency to the values, and the instrument currency to the prices,
+// done by the client once decoded.
+func (order *Order) AttachCurrency() {
+	withCurrency(order.Currency, &order.FilledValue, &order.Value)
+	withCurrency(order.Instrument.Currency, &order.LimitPrice, &order.StopPrice)
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+)
+
+// OrderChanges amends a pending order, the nil and empty fields keep the values of the order.
+type OrderChanges struct {
+	// Quantity, the new total quantity of the order, positive whatever its side.
+	Quantity *Quantity
+	// LimitPrice of the limit and stop-limit orders.
+	LimitPrice *Decimal
+	// StopPrice of the stop and stop-limit orders.
+	StopPrice *Decimal
+	// TimeInForce of the limit and stop-limit orders.
+	TimeInForce TimeInForce
+}
+
+// TotalQuantity returns the quantity of the order once amended, positive.
+func (changes OrderChanges) TotalQuantity(order *Order) Quantity {
+	if changes.Quantity != nil {
+		return changes.Quantity.Abs()
+	}
+
+	return order.Quantity.Abs()
+}
+
+// Replacement returns the request placing the order with the changes for the quantity, positive
+// whatever the side of the order: a LimitOrderRequest, a StopOrderRequest or a StopLimitOrderRequest.
+func (changes OrderChanges) Replacement(order *Order, quantity Quantity) (any, error) {
+	err := changes.validate(order.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity = quantity.Abs()
+	if order.Side == OrderSideSell || order.Quantity.Sign() < 0 {
+		quantity = quantity.Neg()
+	}
+
+	base := baseOrderRequest{Ticker: order.Ticker, Quantity: quantity}
+	limit := baseLimitOrderRequest{
+		LimitPrice:  valueOr(changes.LimitPrice, order.LimitPrice.Amount),
+		TimeInForce: cmp.Or(changes.TimeInForce, order.TimeInForce),
+	}
+	stop := baseStopOrderRequest{StopPrice: valueOr(changes.StopPrice, order.StopPrice.Amount)}
+
+	switch order.Type { //nolint:exhaustive // the other types are refused by validate
+	case OrderTypeLimit:
+		req := LimitOrderRequest{baseOrderRequest: base, baseLimitOrderRequest: limit}
+
+		return req, req.Validate()
+	case OrderTypeStop:
+		req := StopOrderRequest{baseOrderRequest: base, baseStopOrderRequest: stop}
+
+		return req, req.Validate()
+	default:
+		req := StopLimitOrderRequest{baseOrderRequest: base, baseLimitOrderRequest: limit, baseStopOrderRequest: stop}
+
+		return req, req.Validate()
+	}
+}
+
+// validate the changes against the type of the order.
+func (changes OrderChanges) validate(orderType OrderType) error {
+	const request = "OrderChanges"
+
+	var errs []error
+
+	hasLimit := orderType == OrderTypeLimit || orderType == OrderTypeStopLimit
+	hasStop := orderType == OrderTypeStop || orderType == OrderTypeStopLimit
+
+	if !hasLimit && !hasStop {
+		return requestError(request, "type", fmt.Errorf("%w: got %q", errAmendOrderType, orderType))
+	}
+
+	if changes.Quantity != nil && changes.Quantity.Sign() <= 0 {
+		errs = append(errs, requestError(request, "quantity",
+			fmt.Errorf("%w: got %v", errAmendQuantity, *changes.Quantity)))
+	}
+
+	if !hasLimit && changes.LimitPrice != nil {
+		errs = append(errs, requestError(request, "limitPrice", fmt.Errorf("%w: %s", errAmendField, orderType)))
+	}
+
+	if !hasLimit && changes.TimeInForce != "" {
+		errs = append(errs, requestError(request, "timeInForce", fmt.Errorf("%w: %s", errAmendField, orderType)))
+	}
+
+	if !hasStop && changes.StopPrice != nil {
+		errs = append(errs, requestError(request, "stopPrice", fmt.Errorf("%w: %s", errAmendField, orderType)))
+	}
+
+	return errors.Join(errs...)
+}
+
+func valueOr(value *Decimal, fallback Decimal) Decimal {
+	if value != nil {
+		return *value
+	}
+
+	return fallback
+}
@@ -0,0 +1,332 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate the order request.
+func (req LimitOrderRequest) Validate() error {
+	const request = "LimitOrderRequest"
+
+	return errors.Join(req.validateOrder(request), req.validateLimit(request))
+}
+
+// Validate the order request.
+func (req MarketOrderRequest) Validate() error {
+	return req.validateOrder("MarketOrderRequest")
+}
+
+// Validate the order request.
+func (req StopOrderRequest) Validate() error {
+	const request = "StopOrderRequest"
+
+	return errors.Join(req.validateOrder(request), req.validateStop(request))
+}
+
+// Validate the order request.
+func (req StopLimitOrderRequest) Validate() error {
+	const request = "StopLimitOrderRequest"
+
+	return errors.Join(req.validateOrder(request), req.validateLimit(request), req.validateStop(request))
+}
+
+func (req baseOrderRequest) validateOrder(request string) error {
+	var errs []error
+
+	if err := req.Ticker.Validate(); err != nil {
+		errs = append(errs, requestError(request, "ticker", err))
+	}
+
+	if req.Quantity.IsZero() {
+		errs = append(errs, requestError(request, "quantity", fmt.Errorf("%w: got %v", errQuantity, req.Quantity)))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (req baseLimitOrderRequest) validateLimit(request string) error {
+	var errs []error
+
+	if req.LimitPrice.Sign() <= 0 {
+		errs = append(errs, requestError(request, "limitPrice", fmt.Errorf("%w: got %v", errPrice, req.LimitPrice)))
+	}
+
+	if req.TimeInForce != "" && !req.TimeInForce.IsKnown() {
+		errs = append(errs, requestError(request, "timeInForce",
+			fmt.Errorf("%w: got %q", errTimeInForce, req.TimeInForce)))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (req baseStopOrderRequest) validateStop(request string) error {
+	if req.StopPrice.Sign() <= 0 {
+		return requestError(request, "stopPrice", fmt.Errorf("%w: got %v", errPrice, req.StopPrice))
+	}
+
+	return nil
+}
+
+// signedQuantity returns the quantity signed for the side, with an error when it is negative.
+func signedQuantity(request string, quantity float64, sell bool) (Quantity, error) {
+	decimal, err := toDecimal(request, "quantity", quantity)
+	if err == nil && quantity < 0 {
+		err = requestError(request, "quantity", fmt.Errorf("%w: got %v", errSide, quantity))
+	}
+
+	if sell {
+		return decimal.Abs().Neg(), err
+	}
+
+	return decimal.Abs(), err
+}
+
+// toDecimal converts a builder argument.
+func toDecimal(request, field string, value float64) (Decimal, error) {
+	decimal, err := DecimalFromFloat(value)
+	if err != nil {
+		return Decimal{}, requestError(request, field, err)
+	}
+
+	return decimal, nil
+}
+
+// LimitOrderBuilder builds a LimitOrderRequest, see NewLimitOrder.
+type LimitOrderBuilder struct {
+	req  LimitOrderRequest
+	errs []error
+}
+
+// NewLimitOrder starts a limit order of the instrument, e.g.
+//
+//	req, err := NewLimitOrder("AAPL_US_EQ").Buy(10).At(150).GTC().Build()
+func NewLimitOrder(ticker Ticker) *LimitOrderBuilder {
+	builder := &LimitOrderBuilder{req: LimitOrderRequest{}, errs: nil}
+	builder.req.Ticker = ticker
+
+	return builder
+}
+
+// Buy the quantity.
+func (b *LimitOrderBuilder) Buy(quantity float64) *LimitOrderBuilder {
+	return b.side(quantity, false)
+}
+
+// Sell the quantity.
+func (b *LimitOrderBuilder) Sell(quantity float64) *LimitOrderBuilder {
+	return b.side(quantity, true)
+}
+
+func (b *LimitOrderBuilder) side(quantity float64, sell bool) *LimitOrderBuilder {
+	var err error
+
+	b.req.Quantity, err = signedQuantity("LimitOrderRequest", quantity, sell)
+	b.errs = append(b.errs, err)
+
+	return b
+}
+
+// At the limit price.
+func (b *LimitOrderBuilder) At(limitPrice float64) *LimitOrderBuilder {
+	var err error
+
+	b.req.LimitPrice, err = toDecimal("LimitOrderRequest", "limitPrice", limitPrice)
+	b.errs = append(b.errs, err)
+
+	return b
+}
+
+// GTC keeps the order until filled or cancelled.
+func (b *LimitOrderBuilder) GTC() *LimitOrderBuilder {
+	b.req.TimeInForce = TimeInForceGoodTillCancel
+
+	return b
+}
+
+// Day expires the order at the end of the day.
+func (b *LimitOrderBuilder) Day() *LimitOrderBuilder {
+	b.req.TimeInForce = TimeInForceDay
+
+	return b
+}
+
+// Build returns the request, only usable when the error is nil.
+// The error joins a *RequestError per invalid field.
+func (b *LimitOrderBuilder) Build() (LimitOrderRequest, error) {
+	return b.req, errors.Join(append(b.errs, b.req.Validate())...)
+}
+
+// MarketOrderBuilder builds a MarketOrderRequest, see NewMarketOrder.
+type MarketOrderBuilder struct {
+	req  MarketOrderRequest
+	errs []error
+}
+
+// NewMarketOrder starts a market order of the instrument, e.g.
+//
+//	req, err := NewMarketOrder("AAPL_US_EQ").Sell(10).ExtendedHours().Build()
+func NewMarketOrder(ticker Ticker) *MarketOrderBuilder {
+	builder := &MarketOrderBuilder{req: MarketOrderRequest{}, errs: nil}
+	builder.req.Ticker = ticker
+
+	return builder
+}
+
+// Buy the quantity.
+func (b *MarketOrderBuilder) Buy(quantity float64) *MarketOrderBuilder {
+	return b.side(quantity, false)
+}
+
+// Sell the quantity.
+func (b *MarketOrderBuilder) Sell(quantity float64) *MarketOrderBuilder {
+	return b.side(quantity, true)
+}
+
+func (b *MarketOrderBuilder) side(quantity float64, sell bool) *MarketOrderBuilder {
+	var err error
+
+	b.req.Quantity, err = signedQuantity("MarketOrderRequest", quantity, sell)
+	b.errs = append(b.errs, err)
+
+	return b
+}
+
+// ExtendedHours allows the order to fill outside the regular trading session.
+func (b *MarketOrderBuilder) ExtendedHours() *MarketOrderBuilder {
+	b.req.ExtendedHours = true
+
+	return b
+}
+
+// Build returns the request, only usable when the error is nil.
+// The error joins a *RequestError per invalid field.
+func (b *MarketOrderBuilder) Build() (MarketOrderRequest, error) {
+	return b.req, errors.Join(append(b.errs, b.req.Validate())...)
+}
+
+// StopOrderBuilder builds a StopOrderRequest, see NewStopOrder.
+type StopOrderBuilder struct {
+	req  StopOrderRequest
+	errs []error
+}
+
+// NewStopOrder starts a stop order of the instrument, e.g.
+//
+//	req, err := NewStopOrder("AAPL_US_EQ").Sell(10).Stop(140).Build()
+func NewStopOrder(ticker Ticker) *StopOrderBuilder {
+	builder := &StopOrderBuilder{req: StopOrderRequest{}, errs: nil}
+	builder.req.Ticker = ticker
+
+	return builder
+}
+
+// Buy the quantity.
+func (b *StopOrderBuilder) Buy(quantity float64) *StopOrderBuilder {
+	return b.side(quantity, false)
+}
+
+// Sell the quantity.
+func (b *StopOrderBuilder) Sell(quantity float64) *StopOrderBuilder {
+	return b.side(quantity, true)
+}
+
+func (b *StopOrderBuilder) side(quantity float64, sell bool) *StopOrderBuilder {
+	var err error
+
+	b.req.Quantity, err = signedQuantity("StopOrderRequest", quantity, sell)
+	b.errs = append(b.errs, err)
+
+	return b
+}
+
+// Stop at the price, triggering a market order.
+func (b *StopOrderBuilder) Stop(stopPrice float64) *StopOrderBuilder {
+	var err error
+
+	b.req.StopPrice, err = toDecimal("StopOrderRequest", "stopPrice", stopPrice)
+	b.errs = append(b.errs, err)
+
+	return b
+}
+
+// Build returns the request, only usable when the error is nil.
+// The error joins a *RequestError per invalid field.
+func (b *StopOrderBuilder) Build() (StopOrderRequest, error) {
+	return b.req, errors.Join(append(b.errs, b.req.Validate())...)
+}
+
+// StopLimitOrderBuilder builds a StopLimitOrderRequest, see NewStopLimitOrder.
+type StopLimitOrderBuilder struct {
+	req  StopLimitOrderRequest
+	errs []error
+}
+
+// NewStopLimitOrder starts a stop-limit order of the instrument, e.g.
+//
+//	req, err := NewStopLimitOrder("AAPL_US_EQ").Buy(10).Stop(150).At(152).Day().Build()
+func NewStopLimitOrder(ticker Ticker) *StopLimitOrderBuilder {
+	builder := &StopLimitOrderBuilder{req: StopLimitOrderRequest{}, errs: nil}
+	builder.req.Ticker = ticker
+
+	return builder
+}
+
+// Buy the quantity.
+func (b *StopLimitOrderBuilder) Buy(quantity float64) *StopLimitOrderBuilder {
+	return b.side(quantity, false)
+}
+
+// Sell the quantity.
+func (b *StopLimitOrderBuilder) Sell(quantity float64) *StopLimitOrderBuilder {
+	return b.side(quantity, true)
+}
+
+func (b *StopLimitOrderBuilder) side(quantity float64, sell bool) *StopLimitOrderBuilder {
+	var err error
+
+	b.req.Quantity, err = signedQuantity("StopLimitOrderRequest", quantity, sell)
+	b.errs = append(b.errs, err)
+
+	return b
+}
+
+// Stop at the price, triggering the limit order.
+func (b *StopLimitOrderBuilder) Stop(stopPrice float64) *StopLimitOrderBuilder {
+	var err error
+
+	b.req.StopPrice, err = toDecimal("StopLimitOrderRequest", "stopPrice", stopPrice)
+	b.errs = append(b.errs, err)
+
+	return b
+}
+
+// At the limit price, once triggered.
+func (b *StopLimitOrderBuilder) At(limitPrice float64) *StopLimitOrderBuilder {
+	var err error
+
+	b.req.LimitPrice, err = toDecimal("StopLimitOrderRequest", "limitPrice", limitPrice)
+	b.errs = append(b.errs, err)
+
+	return b
+}
+
+// GTC keeps the order until filled or cancelled.
+func (b *StopLimitOrderBuilder) GTC() *StopLimitOrderBuilder {
+	b.req.TimeInForce = TimeInForceGoodTillCancel
+
+	return b
+}
+
+// Day expires the order at the end of the day.
+func (b *StopLimitOrderBuilder) Day() *StopLimitOrderBuilder {
+	b.req.TimeInForce = TimeInForceDay
+
+	return b
+}
+
+// Build returns the request, only usable when the error is nil.
+// The error joins a *RequestError per invalid field.
+func (b *StopLimitOrderBuilder) Build() (StopLimitOrderRequest, error) {
+	return b.req, errors.Join(append(b.errs, b.req.Validate())...)
+}
@@ -5,15 +5,15 @@ import "time"
 // PieSummary response type.
 type PieSummary struct {
 	// Cash.
-	Cash float64 `json:"cash"`
+	Cash Money `json:"cash"`
 	// DividendDetails.
 	DividendDetails struct {
 		// Gained.
-		Gained float64 `json:"gained"`
+		Gained Money `json:"gained"`
 		// InCash.
-		InCash float64 `json:"inCash"`
+		InCash Money `json:"inCash"`
 		// Reinvested.
-		Reinvested float64 `json:"reinvested"`
+		Reinvested Money `json:"reinvested"`
 	} `json:"dividendDetails"`
 	// ID.
 	ID uint `json:"id"`
@@ -22,13 +22,13 @@ type PieSummary struct {
 	// Result.
 	Result struct {
 		// PriceAvgInvestedValue.
-		PriceAvgInvestedValue float64 `json:"priceAvgInvestedValue"`
+		PriceAvgInvestedValue Money `json:"priceAvgInvestedValue"`
 		// PriceAvgResult.
-		PriceAvgResult float64 `json:"priceAvgResult"`
+		PriceAvgResult Money `json:"priceAvgResult"`
 		// PriceAvgResultCoef.
 		PriceAvgResultCoef float64 `json:"priceAvgResultCoef"`
 		// PriceAvgValue.
-		PriceAvgValue float64 `json:"priceAvgValue"`
+		PriceAvgValue Money `json:"priceAvgValue"`
 	} `json:"result"`
 	// Status.
 	Status string `json:"status"`
@@ -43,23 +43,23 @@ type PieDetails struct {
 			Name     string `json:"name"`
 			Severity string `json:"severity"`
 		} `json:"issues"`
-		OwnedQuantity float64 `json:"ownedQuantity"`
+		OwnedQuantity Quantity `json:"ownedQuantity"`
 		Result        struct {
-			PriceAvgInvestedValue float64 `json:"priceAvgInvestedValue"`
-			PriceAvgResult        float64 `json:"priceAvgResult"`
+			PriceAvgInvestedValue Money   `json:"priceAvgInvestedValue"`
+			PriceAvgResult        Money   `json:"priceAvgResult"`
 			PriceAvgResultCoef    float64 `json:"priceAvgResultCoef"`
-			PriceAvgValue         float64 `json:"priceAvgValue"`
+			PriceAvgValue         Money   `json:"priceAvgValue"`
 		} `json:"result"`
-		Ticker string `json:"ticker"`
+		Ticker Ticker `json:"ticker"`
 	} `json:"instruments"`
 	Settings struct {
 		ID                 uint               `json:"id"`
 		CreationDate       time.Time          `json:"creationDate"`
 		DividendCashAction string             `json:"dividendCashAction"`
 		EndDate            time.Time          `json:"endDate"`
-		Goal               float64            `json:"goal"`
+		Goal               Money              `json:"goal"`
 		Icon               string             `json:"icon"`
-		InitialInvestment  float64            `json:"initialInvestment"`
+		InitialInvestment  Money              `json:"initialInvestment"`
 		InstrumentShares   map[string]float64 `json:"instrumentShares"`
 		Name               string             `json:"name"`
 		PublicURL          string             `json:"publicUrl"`
@@ -72,11 +72,9 @@ type PieRequest struct {
 
 	DividendCashAction string    `json:"dividendCashAction"`
 	EndDate            time.Time `json:"endDate"`
-	Goal               float64   `json:"goal"`
-	InstrumentShares   struct {
-		AAPLUSEQ float64 `json:"AAPL_US_EQ"` //nolint:tagliatelle
-		MSFTUSEQ float64 `json:"MSFT_US_EQ"` //nolint:tagliatelle
-	} `json:"instrumentShares"`
+	Goal               Money     `json:"goal"`
+	// Weights of the instruments by ticker, summing to 1, see NormalizeShares.
+	InstrumentShares map[string]float64 `json:"instrumentShares"`
 }
 
 // PieMetaRequest request type.
@@ -0,0 +1,235 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PieSharesTolerance is the tolerated difference between the sum of the pie shares and 1.
+const PieSharesTolerance = 1e-4
+
+// What to do with the dividends of a pie.
+const (
+	// DividendCashActionReinvest reinvests the dividends in the pie.
+	DividendCashActionReinvest = "REINVEST"
+	// DividendCashActionToAccountCash pays the dividends out to the account cash.
+	DividendCashActionToAccountCash = "TO_ACCOUNT_CASH"
+)
+
+// Validate the pie request.
+func (req PieRequest) Validate() error {
+	const request = "PieRequest"
+
+	var errs []error
+
+	if req.Name == "" {
+		errs = append(errs, requestError(request, "name", errEmptyName))
+	}
+
+	if req.Goal.Sign() < 0 {
+		errs = append(errs, requestError(request, "goal", fmt.Errorf("%w: got %v", errNegative, req.Goal)))
+	}
+
+	switch req.DividendCashAction {
+	case "", DividendCashActionReinvest, DividendCashActionToAccountCash:
+	default:
+		errs = append(errs, requestError(request, "dividendCashAction",
+			fmt.Errorf("%w: got %q", errDividendCashAction, req.DividendCashAction)))
+	}
+
+	return errors.Join(append(errs, validateShares(request, req.InstrumentShares))...)
+}
+
+// validateShares checks the tickers and the weights, and that they sum to 1.
+func validateShares(request string, shares map[string]float64) error {
+	if len(shares) == 0 {
+		return requestError(request, "instrumentShares", errNoShares)
+	}
+
+	var errs []error
+
+	seen := make(map[string]string, len(shares))
+	sum := 0.0
+
+	for _, ticker := range slices.Sorted(maps.Keys(shares)) {
+		field := "instrumentShares." + ticker
+		weight := shares[ticker]
+		sum += weight
+
+		if !tickerPattern.MatchString(ticker) {
+			errs = append(errs, requestError(request, field, fmt.Errorf("%w: got %q", errTicker, ticker)))
+		}
+
+		if other, ok := seen[strings.ToUpper(ticker)]; ok {
+			errs = append(errs, requestError(request, field, fmt.Errorf("%w: %q and %q", errDuplicateTicker, other, ticker)))
+		}
+
+		seen[strings.ToUpper(ticker)] = ticker
+
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			errs = append(errs, requestError(request, field, fmt.Errorf("%w: got %v", errNegative, weight)))
+		}
+	}
+
+	if len(errs) == 0 && math.Abs(sum-1) > PieSharesTolerance {
+		errs = append(errs, requestError(request, "instrumentShares", fmt.Errorf("%w: got %v", errSharesSum, sum)))
+	}
+
+	return errors.Join(errs...)
+}
+
+// NormalizeShares returns a copy of the shares scaled to sum to 1.
+func NormalizeShares(shares map[string]float64) (map[string]float64, error) {
+	sum := 0.0
+
+	for ticker, weight := range shares {
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return nil, fmt.Errorf("%w: got %v for %q", errNegative, weight, ticker)
+		}
+
+		sum += weight
+	}
+
+	if sum == 0 {
+		return nil, fmt.Errorf("%w: got %v", errSharesSum, sum)
+	}
+
+	normalized := make(map[string]float64, len(shares))
+	for ticker, weight := range shares {
+		normalized[ticker] = weight / sum
+	}
+
+	return normalized, nil
+}
+
+// PieRequestBuilder builds a PieRequest, see NewPieRequest.
+type PieRequestBuilder struct {
+	req  PieRequest
+	errs []error
+}
+
+// NewPieRequest starts a pie request, e.g.
+//
+//	req, err := NewPieRequest("Tech").Goal(10000).Reinvest().Build()
+func NewPieRequest(name string) *PieRequestBuilder {
+	builder := &PieRequestBuilder{req: PieRequest{}, errs: nil}
+	builder.req.Name = name
+
+	return builder
+}
+
+// NewPieRequestFrom starts a pie request from the details of an existing pie, to edit it, e.g.
+//
+//	req, err := NewPieRequestFrom(details).Share("TSLA_US_EQ", 0.2).Normalize().Build()
+//
+// The shares are the instrumentShares of the settings, or the expected shares of the instruments.
+func NewPieRequestFrom(details *PieDetails) *PieRequestBuilder {
+	if details == nil {
+		builder := NewPieRequest("")
+		builder.errs = append(builder.errs, requestError("PieRequest", "details", errNoPieDetails))
+
+		return builder
+	}
+
+	builder := NewPieRequest(details.Settings.Name).Icon(details.Settings.Icon).EndDate(details.Settings.EndDate)
+	builder.req.Goal = details.Settings.Goal
+	builder.req.DividendCashAction = details.Settings.DividendCashAction
+
+	if len(details.Settings.InstrumentShares) > 0 {
+		builder.req.InstrumentShares = maps.Clone(details.Settings.InstrumentShares)
+
+		return builder
+	}
+
+	for _, instrument := range details.Instruments {
+		builder.Share(string(instrument.Ticker), instrument.ExpectedShare)
+	}
+
+	return builder
+}
+
+// Icon of the pie.
+func (b *PieRequestBuilder) Icon(icon string) *PieRequestBuilder {
+	b.req.Icon = icon
+
+	return b
+}
+
+// Goal value of the pie.
+func (b *PieRequestBuilder) Goal(goal float64) *PieRequestBuilder {
+	amount, err := toDecimal("PieRequest", "goal", goal)
+	b.req.Goal = NewMoney(amount, "")
+	b.errs = append(b.errs, err)
+
+	return b
+}
+
+// EndDate of the goal.
+func (b *PieRequestBuilder) EndDate(endDate time.Time) *PieRequestBuilder {
+	b.req.EndDate = endDate
+
+	return b
+}
+
+// Reinvest the dividends in the pie.
+func (b *PieRequestBuilder) Reinvest() *PieRequestBuilder {
+	b.req.DividendCashAction = DividendCashActionReinvest
+
+	return b
+}
+
+// PayOutDividends to the account cash.
+func (b *PieRequestBuilder) PayOutDividends() *PieRequestBuilder {
+	b.req.DividendCashAction = DividendCashActionToAccountCash
+
+	return b
+}
+
+// Share sets the weight of the instrument, use Normalize to scale the weights to sum to 1.
+// Setting the same ticker twice is an error, see Unshare to remove it first.
+func (b *PieRequestBuilder) Share(ticker string, weight float64) *PieRequestBuilder {
+	if b.req.InstrumentShares == nil {
+		b.req.InstrumentShares = make(map[string]float64)
+	}
+
+	if _, ok := b.req.InstrumentShares[ticker]; ok {
+		b.errs = append(b.errs, requestError("PieRequest", "instrumentShares."+ticker,
+			fmt.Errorf("%w: %q", errDuplicateTicker, ticker)))
+	}
+
+	b.req.InstrumentShares[ticker] = weight
+
+	return b
+}
+
+// Unshare removes the instrument.
+func (b *PieRequestBuilder) Unshare(ticker string) *PieRequestBuilder {
+	delete(b.req.InstrumentShares, ticker)
+
+	return b
+}
+
+// Normalize scales the weights to sum to 1.
+func (b *PieRequestBuilder) Normalize() *PieRequestBuilder {
+	normalized, err := NormalizeShares(b.req.InstrumentShares)
+	if err != nil {
+		b.errs = append(b.errs, requestError("PieRequest", "instrumentShares", err))
+
+		return b
+	}
+
+	b.req.InstrumentShares = normalized
+
+	return b
+}
+
+// Build returns the request, only usable when the error is nil.
+// The error joins a *RequestError per invalid field.
+func (b *PieRequestBuilder) Build() (PieRequest, error) {
+	return b.req, errors.Join(append(b.errs, b.req.Validate())...)
+}
@@ -5,11 +5,11 @@ import "time"
 // Position response type.
 type Position struct {
 	// AveragePricePaid.
-	AveragePricePaid float64 `json:"averagePricePaid"`
+	AveragePricePaid Money `json:"averagePricePaid"`
 	// CreatedAt.
 	CreatedAt time.Time `json:"createdAt"`
 	// CurrentPrice.
-	CurrentPrice float64 `json:"currentPrice"`
+	CurrentPrice Money `json:"currentPrice"`
 	// Instrument.
 	Instrument struct {
 		// Currency.
@@ -19,25 +19,33 @@ type Position struct {
 		// Name.
 		Name string `json:"name"`
 		// Ticker.
-		Ticker string `json:"ticker"`
+		Ticker Ticker `json:"ticker"`
 	} `json:"instrument"`
 	// Quantity.
-	Quantity float64 `json:"quantity"`
+	Quantity Quantity `json:"quantity"`
 	// QuantityAvailableForTrading.
-	QuantityAvailableForTrading float64 `json:"quantityAvailableForTrading"`
+	QuantityAvailableForTrading Quantity `json:"quantityAvailableForTrading"`
 	// QuantityInPies.
-	QuantityInPies float64 `json:"quantityInPies"`
+	QuantityInPies Quantity `json:"quantityInPies"`
 	// WalletImpact.
 	WalletImpact struct {
 		// Currency.
 		Currency string `json:"currency"`
 		// CurrentValue.
-		CurrentValue float64 `json:"currentValue"`
+		CurrentValue Money `json:"currentValue"`
 		// FxImpact.
-		FxImpact float64 `json:"fxImpact"`
+		FxImpact Money `json:"fxImpact"`
 		// TotalCost.
-		TotalCost float64 `json:"totalCost"`
+		TotalCost Money `json:"totalCost"`
 		// UnrealizedProfitLoss.
-		UnrealizedProfitLoss float64 `json:"unrealizedProfitLoss"`
+		UnrealizedProfitLoss Money `json:"unrealizedProfitLoss"`
 	} `json:"walletImpact"`
 }
+
+// AttachCurrency sets the instrument currency to the prices, and the wallet currency to the
+// wallet impact, done by the client once decoded.
+func (position *Position) AttachCurrency() {
+	withCurrency(position.Instrument.Currency, &position.AveragePricePaid, &position.CurrentPrice)
+	withCurrency(position.WalletImpact.Currency, &position.WalletImpact.CurrentValue, &position.WalletImpact.FxImpact,
+		&position.WalletImpact.TotalCost, &position.WalletImpact.UnrealizedProfitLoss)
+}
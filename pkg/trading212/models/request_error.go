@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	errTicker      = errors.New("ticker should only contain letters, digits, '.', '_' and '-'")
+	errQuantity    = errors.New("quantity should not be zero")
+	errSide        = errors.New("quantity should be positive, the side is set by Buy or Sell")
+	errPrice       = errors.New("price should be positive")
+	errTimeInForce = errors.New("unknown time in force")
+	errEmptyName   = errors.New("name should not be empty")
+	errTimeRange   = errors.New("time range should be set, and end after it starts")
+	errNoData      = errors.New("at least one kind of data should be included")
+	errNegative    = errors.New("value should not be negative")
+
+	errDividendCashAction = errors.New("unknown dividend cash action")
+	errNoShares           = errors.New("at least one instrument share should be set")
+	errDuplicateTicker    = errors.New("duplicate ticker")
+	errSharesSum          = fmt.Errorf("shares should sum to 1 within %v", PieSharesTolerance)
+	errNoPieDetails       = errors.New("pie details should not be nil")
+
+	errAmendOrderType = errors.New("only the limit, stop and stop-limit orders can be amended")
+	errAmendQuantity  = errors.New("quantity should be positive, the side of the order is kept")
+	errAmendField     = errors.New("field does not apply to the order type")
+)
+
+// RequestError is an invalid field of a request, as reported by the request builders and
+// the Validate methods. Several of them are joined with errors.Join.
+type RequestError struct {
+	// Request type, e.g. "LimitOrderRequest".
+	Request string
+	// Field at fault, as named in json.
+	Field string
+	// Err describing the issue.
+	Err error
+}
+
+// Error implements error.
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("invalid %s.%s: %v", e.Request, e.Field, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+func requestError(request, field string, err error) error {
+	return &RequestError{Request: request, Field: field, Err: err}
+}
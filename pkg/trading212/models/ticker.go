@@ -0,0 +1,129 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var errTickerFormat = errors.New("ticker should be a symbol, a market and an asset class, e.g. AAPL_US_EQ or VUSAl_EQ")
+
+// tickerPattern matches the instruments tickers, e.g. "AAPL_US_EQ".
+var tickerPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// Ticker is the unique identifier of an instrument, made of its symbol, market and asset class:
+//   - "AAPL_US_EQ" is the symbol AAPL on the US market, an equity,
+//   - "VUSAl_EQ" is the symbol VUSA on the exchange "l", the London Stock Exchange.
+//
+// See trading212.Catalog to resolve a ticker, an ISIN or a plain symbol to the instrument.
+type Ticker string
+
+// ParseTicker parses and validates a ticker.
+func ParseTicker(text string) (Ticker, error) {
+	ticker := Ticker(text)
+
+	return ticker, ticker.Validate()
+}
+
+// NewTicker returns the equity ticker of the symbol on the market, e.g. NewTicker("aapl", "US")
+// is "AAPL_US_EQ", and NewTicker("VUSA", "l") is "VUSAl_EQ".
+// The ticker may not exist, see trading212.Catalog to resolve it.
+func NewTicker(symbol, market string) (Ticker, error) {
+	market = normalizeMarket(market)
+	symbol = strings.ToUpper(symbol)
+
+	if len(market) == 1 {
+		return ParseTicker(symbol + market + "_EQ")
+	}
+
+	return ParseTicker(symbol + "_" + market + "_EQ")
+}
+
+// normalizeMarket returns a country market in upper case, e.g. "US", and an exchange in lower case, e.g. "l".
+func normalizeMarket(market string) string {
+	if len(market) == 1 {
+		return strings.ToLower(market)
+	}
+
+	return strings.ToUpper(market)
+}
+
+// Validate the characters and the components of the ticker.
+func (t Ticker) Validate() error {
+	if !tickerPattern.MatchString(string(t)) {
+		return fmt.Errorf("%w: got %q", errTicker, string(t))
+	}
+
+	if symbol, _, assetClass := t.parts(); symbol == "" || assetClass == "" {
+		return fmt.Errorf("%w: got %q", errTickerFormat, string(t))
+	}
+
+	return nil
+}
+
+// Symbol of the instrument on its market, e.g. "AAPL".
+func (t Ticker) Symbol() string {
+	symbol, _, _ := t.parts()
+
+	return symbol
+}
+
+// Market of the instrument: a country, e.g. "US", or a lowercase exchange code, e.g. "l".
+// It is empty when the ticker does not tell it.
+func (t Ticker) Market() string {
+	_, market, _ := t.parts()
+
+	return market
+}
+
+// AssetClass of the instrument, e.g. "EQ" for equities.
+func (t Ticker) AssetClass() string {
+	_, _, assetClass := t.parts()
+
+	return assetClass
+}
+
+// IsMarket reports whether the ticker is on the market, ignoring the case of a country.
+func (t Ticker) IsMarket(market string) bool {
+	return t.Market() == normalizeMarket(market)
+}
+
+// String implements fmt.Stringer.
+func (t Ticker) String() string {
+	return string(t)
+}
+
+// parts splits the ticker, the asset class being after the last '_', then the market is either
+// a country after another '_', or a lowercase exchange code ending the symbol.
+func (t Ticker) parts() (symbol, market, assetClass string) {
+	rest, assetClass, found := cutLast(string(t), "_")
+	if !found {
+		return string(t), "", ""
+	}
+
+	if head, country, found := cutLast(rest, "_"); found && isCountry(country) {
+		return head, country, assetClass
+	}
+
+	if last := len(rest) - 1; last > 0 && unicode.IsLower(rune(rest[last])) {
+		return rest[:last], rest[last:], assetClass
+	}
+
+	return rest, "", assetClass
+}
+
+func cutLast(text, separator string) (before, after string, found bool) {
+	index := strings.LastIndex(text, separator)
+	if index < 0 {
+		return text, "", false
+	}
+
+	return text[:index], text[index+len(separator):], true
+}
+
+// isCountry reports whether the market is a two letters country code, e.g. "US".
+func isCountry(market string) bool {
+	return len(market) == 2 && strings.TrimFunc(market, unicode.IsUpper) == ""
+}
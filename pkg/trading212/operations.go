@@ -1,27 +1,37 @@
 package trading212
 
 import (
+	"context"
 	"io"
 )
 
 // helper function for the operations.
-func runOperation[T any](api requestMaker, method string, endpoint APIEndpoint, body any) *Response[T] {
+func runOperation[T any](
+	ctx context.Context, api requestMaker, method string, endpoint APIEndpoint, body any,
+) *Response[T] {
+	paginator := newPaginator[T](ctx, api, method, endpoint)
+
 	var requestBodyReader io.Reader
 	if body != nil {
-		requestBodyReader = newJSONBody(body)
+		jsonBody, err := newJSONBody(body)
+		if err != nil {
+			return &Response[T]{request: nil, body: nil, err: err, paginator: paginator}
+		}
+
+		requestBodyReader = jsonBody
 	}
 
-	request, err := api.NewRequest(method, endpoint, requestBodyReader)
+	request, err := api.NewRequestWithContext(ctx, method, endpoint, requestBodyReader)
 	if err != nil {
-		return &Response[T]{request: request, raw: nil, err: err}
+		return &Response[T]{request: request, body: nil, err: err, paginator: paginator}
 	}
 
-	data, err := request.Do()
+	responseBody, err := request.stream()
 	if err != nil {
-		return &Response[T]{request: request, raw: data, err: err}
+		return &Response[T]{request: request, body: nil, err: err, paginator: paginator}
 	}
 
-	return &Response[T]{request: request, raw: data, err: nil}
+	return &Response[T]{request: request, body: responseBody, err: nil, paginator: paginator}
 }
 
 // operations regroups all available operations.
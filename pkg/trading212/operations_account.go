@@ -1,6 +1,7 @@
 package trading212
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/cyrbil/go-trading212/pkg/trading212/models"
@@ -12,6 +13,8 @@ type accountOperations interface {
 	// including available funds, invested capital, and total account value.
 	// See: https://docs.trading212.com/api/accounts/getaccountsummary
 	GetAccountSummary() (*models.AccountSummary, error)
+	// GetAccountSummaryWithContext is GetAccountSummary bound to ctx.
+	GetAccountSummaryWithContext(ctx context.Context) (*models.AccountSummary, error)
 }
 
 type account struct {
@@ -19,5 +22,9 @@ type account struct {
 }
 
 func (op *account) GetAccountSummary() (*models.AccountSummary, error) {
-	return runOperation[models.AccountSummary](op.api, http.MethodGet, GetAccountSummary, nil).Object()
+	return op.GetAccountSummaryWithContext(context.Background())
+}
+
+func (op *account) GetAccountSummaryWithContext(ctx context.Context) (*models.AccountSummary, error) {
+	return runOperation[models.AccountSummary](ctx, op.api, http.MethodGet, GetAccountSummary, nil).Object()
 }
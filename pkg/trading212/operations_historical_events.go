@@ -1,17 +1,27 @@
 package trading212
 
 import (
+	"context"
+	"errors"
 	"iter"
 	"net/http"
 
 	"github.com/cyrbil/go-trading212/pkg/trading212/models"
 )
 
+var errInvalidQuery = errors.New("invalid query parameters")
+
 type operationGetPaidOutDividends interface {
 	// GetPaidOutDividends operation.
 	// Get paid out dividends.
 	// See: https://docs.trading212.com/api/historical-events/dividends
 	GetPaidOutDividends() (iter.Seq[*models.Dividend], error)
+	// GetPaidOutDividendsWithContext is GetPaidOutDividends bound to ctx.
+	GetPaidOutDividendsWithContext(ctx context.Context) (iter.Seq[*models.Dividend], error)
+	// GetPaidOutDividendsIter is GetPaidOutDividends matching query, yielding any failure as the last element.
+	GetPaidOutDividendsIter(ctx context.Context, query models.HistoryQuery) iter.Seq2[*models.Dividend, error]
+	// GetPaidOutDividendsPages is GetPaidOutDividendsIter as a resumable Paginator.
+	GetPaidOutDividendsPages(ctx context.Context, query models.HistoryQuery) *Paginator[models.Dividend]
 }
 
 type operationGetHistoricalOrders interface {
@@ -19,6 +29,12 @@ type operationGetHistoricalOrders interface {
 	// Get historical orders data.
 	// See: https://docs.trading212.com/api/historical-events/orders_1
 	GetHistoricalOrders() (iter.Seq[*models.OrderFill], error)
+	// GetHistoricalOrdersWithContext is GetHistoricalOrders bound to ctx.
+	GetHistoricalOrdersWithContext(ctx context.Context) (iter.Seq[*models.OrderFill], error)
+	// GetHistoricalOrdersIter is GetHistoricalOrders matching query, yielding any failure as the last element.
+	GetHistoricalOrdersIter(ctx context.Context, query models.HistoryQuery) iter.Seq2[*models.OrderFill, error]
+	// GetHistoricalOrdersPages is GetHistoricalOrdersIter as a resumable Paginator.
+	GetHistoricalOrdersPages(ctx context.Context, query models.HistoryQuery) *Paginator[models.OrderFill]
 }
 
 type operationGetTransactions interface {
@@ -26,6 +42,12 @@ type operationGetTransactions interface {
 	// Fetch superficial information about movements to and from your account.
 	// See: https://docs.trading212.com/api/historical-events/transactions
 	GetTransactions() (iter.Seq[*models.Transaction], error)
+	// GetTransactionsWithContext is GetTransactions bound to ctx.
+	GetTransactionsWithContext(ctx context.Context) (iter.Seq[*models.Transaction], error)
+	// GetTransactionsIter is GetTransactions matching query, yielding any failure as the last element.
+	GetTransactionsIter(ctx context.Context, query models.TransactionsQuery) iter.Seq2[*models.Transaction, error]
+	// GetTransactionsPages is GetTransactionsIter as a resumable Paginator.
+	GetTransactionsPages(ctx context.Context, query models.TransactionsQuery) *Paginator[models.Transaction]
 }
 
 type operationListReports interface {
@@ -39,6 +61,10 @@ type operationListReports interface {
 	//
 	// See: https://docs.trading212.com/api/historical-events/getreports
 	ListReports() (iter.Seq[*models.Report], error)
+	// ListReportsWithContext is ListReports bound to ctx.
+	ListReportsWithContext(ctx context.Context) (iter.Seq[*models.Report], error)
+	// ListReportsIter is ListReports, yielding any failure as the last element.
+	ListReportsIter(ctx context.Context) iter.Seq2[*models.Report, error]
 }
 
 type operationRequestReport interface {
@@ -49,6 +75,8 @@ type operationRequestReport interface {
 	// /history/exports endpoint.
 	// See: https://docs.trading212.com/api/historical-events/requestreport
 	RequestReport(req models.ReportRequest) (*models.ReportID, error)
+	// RequestReportWithContext is RequestReport bound to ctx.
+	RequestReportWithContext(ctx context.Context, req models.ReportRequest) (*models.ReportID, error)
 }
 
 type historicalEventsOperations interface {
@@ -64,21 +92,98 @@ type historicalEvents struct {
 }
 
 func (op *historicalEvents) GetPaidOutDividends() (iter.Seq[*models.Dividend], error) {
-	return runOperation[models.Dividend](op.api, http.MethodGet, GetDividends, nil).Items()
+	return op.GetPaidOutDividendsWithContext(context.Background())
+}
+
+func (op *historicalEvents) GetPaidOutDividendsWithContext(ctx context.Context) (iter.Seq[*models.Dividend], error) {
+	return runOperation[models.Dividend](ctx, op.api, http.MethodGet, GetDividends, nil).Items()
+}
+
+func (op *historicalEvents) GetPaidOutDividendsIter(
+	ctx context.Context, query models.HistoryQuery,
+) iter.Seq2[*models.Dividend, error] {
+	return op.GetPaidOutDividendsPages(ctx, query).All()
+}
+
+func (op *historicalEvents) GetPaidOutDividendsPages(
+	ctx context.Context, query models.HistoryQuery,
+) *Paginator[models.Dividend] {
+	err := query.Validate()
+	if err != nil {
+		return failedPaginator[models.Dividend](errors.Join(errInvalidQuery, err))
+	}
+
+	return newPaginator[models.Dividend](ctx, op.api, http.MethodGet, GetDividends.withQuery(query.Values()))
 }
 
 func (op *historicalEvents) GetHistoricalOrders() (iter.Seq[*models.OrderFill], error) {
-	return runOperation[models.OrderFill](op.api, http.MethodGet, GetHistoricalOrders, nil).Items()
+	return op.GetHistoricalOrdersWithContext(context.Background())
+}
+
+func (op *historicalEvents) GetHistoricalOrdersWithContext(ctx context.Context) (iter.Seq[*models.OrderFill], error) {
+	return runOperation[models.OrderFill](ctx, op.api, http.MethodGet, GetHistoricalOrders, nil).Items()
+}
+
+func (op *historicalEvents) GetHistoricalOrdersIter(
+	ctx context.Context, query models.HistoryQuery,
+) iter.Seq2[*models.OrderFill, error] {
+	return op.GetHistoricalOrdersPages(ctx, query).All()
+}
+
+func (op *historicalEvents) GetHistoricalOrdersPages(
+	ctx context.Context, query models.HistoryQuery,
+) *Paginator[models.OrderFill] {
+	err := query.Validate()
+	if err != nil {
+		return failedPaginator[models.OrderFill](errors.Join(errInvalidQuery, err))
+	}
+
+	return newPaginator[models.OrderFill](ctx, op.api, http.MethodGet, GetHistoricalOrders.withQuery(query.Values()))
 }
 
 func (op *historicalEvents) GetTransactions() (iter.Seq[*models.Transaction], error) {
-	return runOperation[models.Transaction](op.api, http.MethodGet, GetTransactions, nil).Items()
+	return op.GetTransactionsWithContext(context.Background())
+}
+
+func (op *historicalEvents) GetTransactionsWithContext(ctx context.Context) (iter.Seq[*models.Transaction], error) {
+	return runOperation[models.Transaction](ctx, op.api, http.MethodGet, GetTransactions, nil).Items()
+}
+
+func (op *historicalEvents) GetTransactionsIter(
+	ctx context.Context, query models.TransactionsQuery,
+) iter.Seq2[*models.Transaction, error] {
+	return op.GetTransactionsPages(ctx, query).All()
+}
+
+func (op *historicalEvents) GetTransactionsPages(
+	ctx context.Context, query models.TransactionsQuery,
+) *Paginator[models.Transaction] {
+	err := query.Validate()
+	if err != nil {
+		return failedPaginator[models.Transaction](errors.Join(errInvalidQuery, err))
+	}
+
+	return newPaginator[models.Transaction](ctx, op.api, http.MethodGet, GetTransactions.withQuery(query.Values()))
 }
 
 func (op *historicalEvents) ListReports() (iter.Seq[*models.Report], error) {
-	return runOperation[models.Report](op.api, http.MethodGet, ListReports, nil).Items()
+	return op.ListReportsWithContext(context.Background())
+}
+
+func (op *historicalEvents) ListReportsWithContext(ctx context.Context) (iter.Seq[*models.Report], error) {
+	return runOperation[models.Report](ctx, op.api, http.MethodGet, ListReports, nil).Items()
+}
+
+func (op *historicalEvents) ListReportsIter(ctx context.Context) iter.Seq2[*models.Report, error] {
+	return newPaginator[models.Report](ctx, op.api, http.MethodGet, ListReports).All()
 }
 
 func (op *historicalEvents) RequestReport(req models.ReportRequest) (*models.ReportID, error) {
-	return runOperation[models.ReportID](op.api, http.MethodPost, RequestReport, req).Object()
+	return op.RequestReportWithContext(context.Background(), req)
+}
+
+func (op *historicalEvents) RequestReportWithContext(
+	ctx context.Context, req models.ReportRequest,
+) (*models.ReportID, error) {
+	return runOperation[models.ReportID](ctx, op.api, http.MethodPost, RequestReport, req).Object()
 }
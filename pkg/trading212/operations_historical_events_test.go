@@ -1,9 +1,15 @@
 package trading212
 
 import (
-	models "github.com/cyrbil/go-trading212/pkg/trading212/models"
+	"context"
+	"fmt"
 	"iter"
+	"net/http"
+	"slices"
 	"testing"
+	"time"
+
+	models "github.com/cyrbil/go-trading212/pkg/trading212/models"
 )
 
 func Test_HistoricalEvents_Operations(t *testing.T) {
@@ -171,3 +177,174 @@ func Test_HistoricalEvents_Operations(t *testing.T) {
 		},
 	)
 }
+
+func Test_HistoricalEvents_Query(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		operation func(api *API) error
+		wantPath  APIEndpoint
+		wantQuery string
+		wantErr   bool
+	}{
+		{
+			name: "GetHistoricalOrdersIter should send the ticker and cursor",
+			operation: func(api *API) error {
+				query := models.HistoryQuery{Cursor: 42, Ticker: "AAPL_US_EQ", Limit: 0}
+				for _, err := range api.HistoricalEvents.GetHistoricalOrdersIter(context.Background(), query) {
+					return err
+				}
+
+				return nil
+			},
+			wantPath:  GetHistoricalOrders,
+			wantQuery: "cursor=42&limit=50&ticker=AAPL_US_EQ",
+			wantErr:   false,
+		},
+		{
+			name: "GetPaidOutDividendsIter should send the limit",
+			operation: func(api *API) error {
+				query := models.HistoryQuery{Cursor: 0, Ticker: "", Limit: 10}
+				for _, err := range api.HistoricalEvents.GetPaidOutDividendsIter(context.Background(), query) {
+					return err
+				}
+
+				return nil
+			},
+			wantPath:  GetDividends,
+			wantQuery: "limit=10",
+			wantErr:   false,
+		},
+		{
+			name: "GetTransactionsIter should send the time",
+			operation: func(api *API) error {
+				query := models.TransactionsQuery{
+					Cursor: "abc",
+					Time:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					Limit:  0,
+				}
+				for _, err := range api.HistoricalEvents.GetTransactionsIter(context.Background(), query) {
+					return err
+				}
+
+				return nil
+			},
+			wantPath:  GetTransactions,
+			wantQuery: "cursor=abc&limit=50&time=2024-01-02T03%3A04%3A05Z",
+			wantErr:   false,
+		},
+		{
+			name: "GetHistoricalOrdersIter should reject an invalid query",
+			operation: func(api *API) error {
+				query := models.HistoryQuery{Cursor: -1, Ticker: "AAPL US", Limit: 51}
+				for _, err := range api.HistoricalEvents.GetHistoricalOrdersIter(context.Background(), query) {
+					return err
+				}
+
+				return nil
+			},
+			wantPath:  "",
+			wantQuery: "",
+			wantErr:   true,
+		},
+		{
+			name: "Non-paginated endpoints should not send a limit",
+			operation: func(api *API) error {
+				_, err := api.HistoricalEvents.ListReports()
+
+				return err
+			},
+			wantPath:  ListReports,
+			wantQuery: "",
+			wantErr:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotPath APIEndpoint
+
+			var gotQuery string
+
+			mockAPI, terminate, err := newMockAPI(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						gotPath = APIEndpoint(r.URL.Path)
+						gotQuery = r.URL.RawQuery
+						_, _ = fmt.Fprint(w, `[]`)
+					},
+				),
+			)
+			defer terminate()
+
+			if err != nil {
+				t.Fatalf("Error creating mock api; %v", err)
+			}
+
+			err = tt.operation(mockAPI)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("operation error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if gotPath != tt.wantPath || gotQuery != tt.wantQuery {
+				t.Errorf("request = %s?%s, want %s?%s", gotPath, gotQuery, tt.wantPath, tt.wantQuery)
+			}
+		})
+	}
+}
+
+func Test_HistoricalEvents_ReportRequest(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name       string
+		builder    *models.ReportRequestBuilder
+		wantFields []string
+	}{
+		{
+			name:       "orders of a month",
+			builder:    models.NewReportRequest().From(from).To(to).IncludeOrders(),
+			wantFields: nil,
+		},
+		{
+			name:       "everything",
+			builder:    models.NewReportRequest().From(from).To(to).IncludeAll(),
+			wantFields: nil,
+		},
+		{
+			name:       "no data",
+			builder:    models.NewReportRequest().From(from).To(to),
+			wantFields: []string{"dataIncluded"},
+		},
+		{
+			name:       "reversed range",
+			builder:    models.NewReportRequest().From(to).To(from).IncludeDividends(),
+			wantFields: []string{"timeTo"},
+		},
+		{
+			name:       "empty",
+			builder:    models.NewReportRequest(),
+			wantFields: []string{"dataIncluded", "timeTo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := tt.builder.Build()
+			if got := requestErrorFields(err); !slices.Equal(got, tt.wantFields) {
+				t.Errorf("Build() error = %v, want invalid fields %v", err, tt.wantFields)
+			}
+
+			if err == nil && (!req.TimeFrom.Equal(from) || !req.TimeTo.Equal(to)) {
+				t.Errorf("Build() = %+v", req)
+			}
+		})
+	}
+}
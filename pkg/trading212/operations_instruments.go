@@ -1,6 +1,7 @@
 package trading212
 
 import (
+	"context"
 	"iter"
 	"net/http"
 
@@ -12,6 +13,8 @@ type operationGetExchangesMetadata interface {
 	// Retrieves all accessible exchanges and their corresponding working schedules. Data is refreshed every 10 minutes.
 	// See: https://docs.trading212.com/api/instruments/exchanges
 	GetExchangesMetadata() (iter.Seq[*models.ExchangeMetadata], error)
+	// GetExchangesMetadataWithContext is GetExchangesMetadata bound to ctx.
+	GetExchangesMetadataWithContext(ctx context.Context) (iter.Seq[*models.ExchangeMetadata], error)
 }
 
 type operationGetAllAvailableInstruments interface {
@@ -19,11 +22,16 @@ type operationGetAllAvailableInstruments interface {
 	// Retrieves all accessible instruments. Data is refreshed every 10 minutes.
 	// See: https://docs.trading212.com/api/instruments/instruments
 	GetAllAvailableInstruments() (iter.Seq[*models.Instrument], error)
+	// GetAllAvailableInstrumentsWithContext is GetAllAvailableInstruments bound to ctx.
+	GetAllAvailableInstrumentsWithContext(ctx context.Context) (iter.Seq[*models.Instrument], error)
 }
 
 type instrumentsOperations interface {
 	operationGetExchangesMetadata
 	operationGetAllAvailableInstruments
+
+	// availableInstruments walks the available instruments, yielding any failure as the last element.
+	availableInstruments(ctx context.Context) iter.Seq2[*models.Instrument, error]
 }
 
 type instruments struct {
@@ -31,9 +39,25 @@ type instruments struct {
 }
 
 func (op *instruments) GetExchangesMetadata() (iter.Seq[*models.ExchangeMetadata], error) {
-	return runOperation[models.ExchangeMetadata](op.api, http.MethodGet, GetExchangesMetadata, nil).Items()
+	return op.GetExchangesMetadataWithContext(context.Background())
+}
+
+func (op *instruments) GetExchangesMetadataWithContext(
+	ctx context.Context,
+) (iter.Seq[*models.ExchangeMetadata], error) {
+	return runOperation[models.ExchangeMetadata](ctx, op.api, http.MethodGet, GetExchangesMetadata, nil).Items()
 }
 
 func (op *instruments) GetAllAvailableInstruments() (iter.Seq[*models.Instrument], error) {
-	return runOperation[models.Instrument](op.api, http.MethodGet, GetAllAvailableInstruments, nil).Items()
+	return op.GetAllAvailableInstrumentsWithContext(context.Background())
+}
+
+func (op *instruments) GetAllAvailableInstrumentsWithContext(
+	ctx context.Context,
+) (iter.Seq[*models.Instrument], error) {
+	return runOperation[models.Instrument](ctx, op.api, http.MethodGet, GetAllAvailableInstruments, nil).Items()
+}
+
+func (op *instruments) availableInstruments(ctx context.Context) iter.Seq2[*models.Instrument, error] {
+	return runOperation[models.Instrument](ctx, op.api, http.MethodGet, GetAllAvailableInstruments, nil).All()
 }
@@ -0,0 +1,250 @@
+package trading212
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+// defaultCacheTTL matches the refresh period of the instruments data.
+const defaultCacheTTL = 10 * time.Minute
+
+// InstrumentsCache caches the instruments and exchanges metadata, see WithInstrumentsCache.
+// Expired data is still served when refreshing it fails with a retryable error, like being rate-limited.
+// A cache must only be shared between clients of the same account.
+type InstrumentsCache struct {
+	// TTL after which the data is refreshed. Defaults to 10 minutes, the refresh period of the API.
+	TTL time.Duration
+	// Store persisting the data. Defaults to a MemoryCacheStore, use a FileCacheStore to survive restarts.
+	Store CacheStore
+	// BackgroundRefresh serves the expired data immediately, while refreshing it in the background.
+	BackgroundRefresh bool
+
+	now         func() time.Time
+	logger      *slog.Logger
+	exchanges   cachedList[models.ExchangeMetadata]
+	instruments cachedList[models.Instrument]
+}
+
+// NewInstrumentsCache create an InstrumentsCache with the default values.
+func NewInstrumentsCache() *InstrumentsCache {
+	return &InstrumentsCache{
+		TTL:               defaultCacheTTL,
+		Store:             NewMemoryCacheStore(),
+		BackgroundRefresh: false,
+		now:               time.Now,
+		logger:            slog.Default(),
+		exchanges:         newCachedList[models.ExchangeMetadata]("exchanges"),
+		instruments:       newCachedList[models.Instrument]("instruments"),
+	}
+}
+
+// cachedList is a cached response, loaded from the store on first use.
+type cachedList[T any] struct {
+	mutex sync.Mutex
+	key   string
+	items []*T
+	// storedAt the items, zero while nothing is cached, the items being possibly empty.
+	storedAt   time.Time
+	loaded     bool
+	refreshing bool
+}
+
+func newCachedList[T any](key string) cachedList[T] {
+	return cachedList[T]{
+		mutex:      sync.Mutex{},
+		key:        key,
+		items:      nil,
+		storedAt:   time.Time{},
+		loaded:     false,
+		refreshing: false,
+	}
+}
+
+// get returns the cached items, fetching them when missing or expired.
+func (list *cachedList[T]) get(
+	ctx context.Context, cache *InstrumentsCache, fetch func(ctx context.Context) ([]*T, error),
+) ([]*T, error) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if !list.loaded {
+		list.load(cache)
+	}
+
+	if list.cached() && cache.now().Sub(list.storedAt) < cache.TTL {
+		return list.items, nil
+	}
+
+	if list.cached() && cache.BackgroundRefresh {
+		if !list.refreshing {
+			list.refreshing = true
+
+			go list.refresh(context.WithoutCancel(ctx), cache, fetch)
+		}
+
+		return list.items, nil
+	}
+
+	items, err := fetch(ctx)
+	if err != nil {
+		if list.cached() && IsRetryable(err) {
+			cache.logger.Warn("Serving stale cache", "key", list.key, "storedAt", list.storedAt, "error", err)
+
+			return list.items, nil
+		}
+
+		return nil, err
+	}
+
+	list.set(cache, items)
+
+	return items, nil
+}
+
+// cached reports whether items were fetched or loaded, even if none.
+func (list *cachedList[T]) cached() bool {
+	return !list.storedAt.IsZero()
+}
+
+// refresh fetches the items in the background, keeping the stale ones on failure.
+func (list *cachedList[T]) refresh(
+	ctx context.Context, cache *InstrumentsCache, fetch func(ctx context.Context) ([]*T, error),
+) {
+	items, err := fetch(ctx)
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.refreshing = false
+
+	if err != nil {
+		cache.logger.Warn("Fail to refresh cache", "key", list.key, "storedAt", list.storedAt, "error", err)
+
+		return
+	}
+
+	list.set(cache, items)
+}
+
+// load reads the items persisted by a previous process, even if expired.
+func (list *cachedList[T]) load(cache *InstrumentsCache) {
+	list.loaded = true
+
+	data, storedAt, err := cache.Store.Load(list.key)
+	if err != nil {
+		cache.logger.Warn("Fail to load cache", "key", list.key, "error", err)
+
+		return
+	}
+
+	if data == nil {
+		return
+	}
+
+	var items []*T
+
+	err = json.Unmarshal(data, &items)
+	if err != nil {
+		cache.logger.Warn("Fail to decode cache", "key", list.key, "error", err)
+
+		return
+	}
+
+	list.items = items
+	list.storedAt = storedAt
+}
+
+// set replaces the items, then persists them.
+func (list *cachedList[T]) set(cache *InstrumentsCache, items []*T) {
+	list.items = items
+	list.storedAt = cache.now()
+
+	data, err := json.Marshal(items)
+	if err == nil {
+		err = cache.Store.Store(list.key, data, list.storedAt)
+	}
+
+	if err != nil {
+		cache.logger.Warn("Fail to store cache", "key", list.key, "error", err)
+	}
+}
+
+// cachedInstruments serves the instrumentsOperations from an InstrumentsCache.
+type cachedInstruments struct {
+	api   requestMaker
+	cache *InstrumentsCache
+}
+
+func (op *cachedInstruments) GetExchangesMetadata() (iter.Seq[*models.ExchangeMetadata], error) {
+	return op.GetExchangesMetadataWithContext(context.Background())
+}
+
+func (op *cachedInstruments) GetExchangesMetadataWithContext(
+	ctx context.Context,
+) (iter.Seq[*models.ExchangeMetadata], error) {
+	items, err := op.cache.exchanges.get(ctx, op.cache, func(ctx context.Context) ([]*models.ExchangeMetadata, error) {
+		return fetchAll[models.ExchangeMetadata](ctx, op.api, GetExchangesMetadata)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Values(items), nil
+}
+
+func (op *cachedInstruments) GetAllAvailableInstruments() (iter.Seq[*models.Instrument], error) {
+	return op.GetAllAvailableInstrumentsWithContext(context.Background())
+}
+
+func (op *cachedInstruments) GetAllAvailableInstrumentsWithContext(
+	ctx context.Context,
+) (iter.Seq[*models.Instrument], error) {
+	items, err := op.cache.instruments.get(ctx, op.cache, func(ctx context.Context) ([]*models.Instrument, error) {
+		return fetchAll[models.Instrument](ctx, op.api, GetAllAvailableInstruments)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Values(items), nil
+}
+
+func (op *cachedInstruments) availableInstruments(ctx context.Context) iter.Seq2[*models.Instrument, error] {
+	return func(yield func(*models.Instrument, error) bool) {
+		instruments, err := op.GetAllAvailableInstrumentsWithContext(ctx)
+		if err != nil {
+			yield(nil, err)
+
+			return
+		}
+
+		for instrument := range instruments {
+			if !yield(instrument, nil) {
+				return
+			}
+		}
+	}
+}
+
+// fetchAll collects every item of a GET operation, failing on any error.
+func fetchAll[T any](ctx context.Context, api requestMaker, endpoint APIEndpoint) ([]*T, error) {
+	var items []*T
+
+	for item, err := range runOperation[T](ctx, api, http.MethodGet, endpoint, nil).All() {
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,232 @@
+package trading212
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type instrumentsCacheMock struct {
+	api    *API
+	cache  *InstrumentsCache
+	calls  atomic.Int32
+	status atomic.Int32
+	name   atomic.Value
+	mutex  sync.Mutex
+	now    time.Time
+}
+
+func newInstrumentsCacheMock(t *testing.T, store CacheStore) *instrumentsCacheMock {
+	t.Helper()
+
+	mock := &instrumentsCacheMock{now: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	mock.status.Store(http.StatusOK)
+	mock.name.Store("v1")
+
+	serve := func(format string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			mock.calls.Add(1)
+
+			status := int(mock.status.Load())
+			if status != http.StatusOK {
+				w.WriteHeader(status)
+
+				return
+			}
+
+			_, _ = fmt.Fprintf(w, format, mock.name.Load())
+		}
+	}
+
+	mockAPI := newRoutesMockAPI(t, map[APIEndpoint]http.HandlerFunc{
+		http.MethodGet + " " + GetExchangesMetadata:       serve(`[{"id": 1, "name": %q}]`),
+		http.MethodGet + " " + GetAllAvailableInstruments: serve(`[{"ticker": "AAPL_US_EQ", "name": %q}]`),
+	})
+
+	mock.cache = NewInstrumentsCache()
+	mock.cache.Store = store
+	mock.cache.now = func() time.Time {
+		mock.mutex.Lock()
+		defer mock.mutex.Unlock()
+
+		return mock.now
+	}
+
+	for _, option := range []Option{WithMaxRetries(0), WithInstrumentsCache(mock.cache)} {
+		err := option(mockAPI)
+		if err != nil {
+			t.Fatalf("Option() error = %v", err)
+		}
+	}
+
+	mockAPI.Instruments = &cachedInstruments{api: mockAPI, cache: mock.cache}
+	mock.api = mockAPI
+
+	return mock
+}
+
+func (mock *instrumentsCacheMock) advance(duration time.Duration) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	mock.now = mock.now.Add(duration)
+}
+
+func (mock *instrumentsCacheMock) instrumentName(t *testing.T) (string, error) {
+	t.Helper()
+
+	instruments, err := mock.api.Instruments.GetAllAvailableInstruments()
+	if err != nil {
+		return "", err
+	}
+
+	for instrument := range instruments {
+		return instrument.Name, nil
+	}
+
+	return "", nil
+}
+
+func Test_InstrumentsCache(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		status    int
+		wantName  string
+		wantErr   bool
+		wantCalls int32
+	}{
+		{name: "refresh once expired", status: http.StatusOK, wantName: "v2", wantErr: false, wantCalls: 2},
+		{name: "stale when rate-limited", status: http.StatusTooManyRequests, wantName: "v1", wantErr: false, wantCalls: 2},
+		{name: "stale on server errors", status: http.StatusBadGateway, wantName: "v1", wantErr: false, wantCalls: 2},
+		{name: "fail on other errors", status: http.StatusUnauthorized, wantName: "", wantErr: true, wantCalls: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mock := newInstrumentsCacheMock(t, NewMemoryCacheStore())
+
+			for range 2 {
+				name, err := mock.instrumentName(t)
+				if err != nil || name != "v1" {
+					t.Fatalf("GetAllAvailableInstruments() = %q, %v, want v1", name, err)
+				}
+			}
+
+			if got := mock.calls.Load(); got != 1 {
+				t.Fatalf("calls = %d, want the second call served from cache", got)
+			}
+
+			mock.advance(defaultCacheTTL)
+			mock.name.Store("v2")
+			mock.status.Store(int32(tt.status))
+
+			name, err := mock.instrumentName(t)
+			if (err != nil) != tt.wantErr || name != tt.wantName {
+				t.Errorf("GetAllAvailableInstruments() = %q, %v, want %q, error %v", name, err, tt.wantName, tt.wantErr)
+			}
+
+			if got := mock.calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func Test_InstrumentsCache_Empty(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	mockAPI := newRoutesMockAPI(t, map[APIEndpoint]http.HandlerFunc{
+		http.MethodGet + " " + GetAllAvailableInstruments: func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			_, _ = w.Write([]byte("[]"))
+		},
+	})
+
+	cache := NewInstrumentsCache()
+	mockAPI.Instruments = &cachedInstruments{api: mockAPI, cache: cache}
+
+	for range 2 {
+		_, err := mockAPI.Instruments.GetAllAvailableInstruments()
+		if err != nil {
+			t.Fatalf("GetAllAvailableInstruments() error = %v", err)
+		}
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("GetAllAvailableInstruments() fetched %d times, want the empty list cached", got)
+	}
+}
+
+func Test_InstrumentsCache_ColdStart(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewFileCacheStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCacheStore() error = %v", err)
+	}
+
+	first := newInstrumentsCacheMock(t, store)
+
+	_, err = first.instrumentName(t)
+	if err != nil {
+		t.Fatalf("GetAllAvailableInstruments() error = %v", err)
+	}
+
+	// a new process, with the same file store
+	second := newInstrumentsCacheMock(t, store)
+
+	name, err := second.instrumentName(t)
+	if err != nil || name != "v1" || second.calls.Load() != 0 {
+		t.Errorf("GetAllAvailableInstruments() = %q, %v, after %d calls, want v1 from the file store",
+			name, err, second.calls.Load())
+	}
+
+	_, err = second.api.Instruments.GetExchangesMetadata()
+	if err != nil || second.calls.Load() != 1 {
+		t.Errorf("GetExchangesMetadata() error = %v, after %d calls, want a separate entry", err, second.calls.Load())
+	}
+}
+
+func Test_InstrumentsCache_BackgroundRefresh(t *testing.T) {
+	t.Parallel()
+
+	mock := newInstrumentsCacheMock(t, NewMemoryCacheStore())
+	mock.cache.BackgroundRefresh = true
+
+	_, err := mock.instrumentName(t)
+	if err != nil {
+		t.Fatalf("GetAllAvailableInstruments() error = %v", err)
+	}
+
+	mock.advance(defaultCacheTTL)
+	mock.name.Store("v2")
+
+	name, err := mock.instrumentName(t)
+	if err != nil || name != "v1" {
+		t.Fatalf("GetAllAvailableInstruments() = %q, %v, want the stale v1", name, err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for name != "v2" && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+
+		name, err = mock.instrumentName(t)
+		if err != nil {
+			t.Fatalf("GetAllAvailableInstruments() error = %v", err)
+		}
+	}
+
+	if name != "v2" || mock.calls.Load() != 2 {
+		t.Errorf("GetAllAvailableInstruments() = %q after %d calls, want v2 after 2", name, mock.calls.Load())
+	}
+}
+
+var _ instrumentsOperations = (*cachedInstruments)(nil)
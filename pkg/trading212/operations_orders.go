@@ -1,6 +1,7 @@
 package trading212
 
 import (
+	"context"
 	"fmt"
 	"iter"
 	"net/http"
@@ -14,6 +15,8 @@ type operationGetAllPendingOrders interface {
 	// This is useful for monitoring the status of your open positions and managing your trading strategy.
 	// See: https://docs.trading212.com/api/orders/orders
 	GetAllPendingOrders() (iter.Seq[*models.Order], error)
+	// GetAllPendingOrdersWithContext is GetAllPendingOrders bound to ctx.
+	GetAllPendingOrdersWithContext(ctx context.Context) (iter.Seq[*models.Order], error)
 }
 
 type operationPlaceLimitOrder interface {
@@ -21,8 +24,12 @@ type operationPlaceLimitOrder interface {
 	// Creates a new Limit order, which executes at a specified price or better.
 	// To place a buy order, use a positive quantity. The order will fill at the limitPrice or lower.
 	// To place a sell order, use a negative quantity. The order will fill at the limitPrice or higher.
+	// Placements are never retried on ambiguous failures (timeout, server or transport error),
+	// the pending and historical orders are searched instead, see OrderOutcomeUnknownError.
 	// See: https://docs.trading212.com/api/orders/placelimitorder
 	PlaceLimitOrder(req models.LimitOrderRequest) (*models.Order, error)
+	// PlaceLimitOrderWithContext is PlaceLimitOrder bound to ctx.
+	PlaceLimitOrderWithContext(ctx context.Context, req models.LimitOrderRequest) (*models.Order, error)
 }
 
 type operationPlaceMarketOrder interface {
@@ -32,8 +39,12 @@ type operationPlaceMarketOrder interface {
 	// To place a sell order, use a negative quantity.
 	// extendedHours: Set to true to allow the order to be filled outside the standard trading session.
 	// If placed when the market is closed, the order will be queued to execute when the market next opens.
+	// Placements are never retried on ambiguous failures (timeout, server or transport error),
+	// the pending and historical orders are searched instead, see OrderOutcomeUnknownError.
 	// See: https://docs.trading212.com/api/orders/placemarketorder
 	PlaceMarketOrder(req models.MarketOrderRequest) (*models.Order, error)
+	// PlaceMarketOrderWithContext is PlaceMarketOrder bound to ctx.
+	PlaceMarketOrderWithContext(ctx context.Context, req models.MarketOrderRequest) (*models.Order, error)
 }
 
 type operationPlaceStopOrder interface {
@@ -42,8 +53,12 @@ type operationPlaceStopOrder interface {
 	// To place a buy stop order, use a positive quantity.
 	// To place a sell stop order (commonly a 'stop-loss'), use a negative quantity.
 	// The stopPrice is triggered by the instrument's Last Traded Price (LTP).
+	// Placements are never retried on ambiguous failures (timeout, server or transport error),
+	// the pending and historical orders are searched instead, see OrderOutcomeUnknownError.
 	// See: https://docs.trading212.com/api/orders/placestoporder_1
 	PlaceStopOrder(req models.StopOrderRequest) (*models.Order, error)
+	// PlaceStopOrderWithContext is PlaceStopOrder bound to ctx.
+	PlaceStopOrderWithContext(ctx context.Context, req models.StopOrderRequest) (*models.Order, error)
 }
 
 type operationPlaceStopLimitOrder interface {
@@ -55,8 +70,12 @@ type operationPlaceStopLimitOrder interface {
 	//   - A Limit order is then automatically placed at the specified limitPrice.
 	//
 	// This two-step process helps protect against price slippage that can occur with a standard Stop order.
+	// Placements are never retried on ambiguous failures (timeout, server or transport error),
+	// the pending and historical orders are searched instead, see OrderOutcomeUnknownError.
 	// See: https://docs.trading212.com/api/orders/placestoporder
 	PlaceStopLimitOrder(req models.StopLimitOrderRequest) (*models.Order, error)
+	// PlaceStopLimitOrderWithContext is PlaceStopLimitOrder bound to ctx.
+	PlaceStopLimitOrderWithContext(ctx context.Context, req models.StopLimitOrderRequest) (*models.Order, error)
 }
 
 type operationCancelOrder interface {
@@ -66,6 +85,8 @@ type operationCancelOrder interface {
 	// successful response indicates the cancellation request was accepted.
 	// See: https://docs.trading212.com/api/orders/cancelorder
 	CancelOrder(id int64) error
+	// CancelOrderWithContext is CancelOrder bound to ctx.
+	CancelOrderWithContext(ctx context.Context, id int64) error
 }
 
 type operationGetPendingOrderByID interface {
@@ -74,6 +95,8 @@ type operationGetPendingOrderByID interface {
 	// This is useful for checking the status of a specific order you have previously placed.
 	// See: https://docs.trading212.com/api/orders/orderbyid
 	GetPendingOrderByID(id int64) (*models.Order, error)
+	// GetPendingOrderByIDWithContext is GetPendingOrderByID bound to ctx.
+	GetPendingOrderByIDWithContext(ctx context.Context, id int64) (*models.Order, error)
 }
 
 type ordersOperations interface {
@@ -84,40 +107,82 @@ type ordersOperations interface {
 	operationPlaceStopLimitOrder
 	operationCancelOrder
 	operationGetPendingOrderByID
+	operationPreflight
+	operationAmendOrder
 }
 
 type orders struct {
 	api requestMaker
+	// instruments to check the orders against, cached when configured.
+	instruments instrumentsOperations
+	// enforcePreflight refuses to place the orders failing the pre-flight checks.
+	enforcePreflight bool
+	// claims of the placed orders, see reconcile.
+	claims *orderClaims
+	// tracker confirms the cancellations of the amended orders.
+	tracker *OrderTracker
 }
 
 func (op *orders) GetAllPendingOrders() (iter.Seq[*models.Order], error) {
-	return runOperation[models.Order](op.api, http.MethodGet, GetAllPendingOrders, nil).Items()
+	return op.GetAllPendingOrdersWithContext(context.Background())
+}
+
+func (op *orders) GetAllPendingOrdersWithContext(ctx context.Context) (iter.Seq[*models.Order], error) {
+	return runOperation[models.Order](ctx, op.api, http.MethodGet, GetAllPendingOrders, nil).Items()
 }
 
 func (op *orders) PlaceLimitOrder(req models.LimitOrderRequest) (*models.Order, error) {
-	return runOperation[models.Order](op.api, http.MethodPost, PlaceLimitOrder, req).Object()
+	return op.PlaceLimitOrderWithContext(context.Background(), req)
+}
+
+func (op *orders) PlaceLimitOrderWithContext(ctx context.Context, req models.LimitOrderRequest) (*models.Order, error) {
+	return op.place(ctx, PlaceLimitOrder, req, describeOrder(req))
 }
 
 func (op *orders) PlaceMarketOrder(req models.MarketOrderRequest) (*models.Order, error) {
-	return runOperation[models.Order](op.api, http.MethodPost, PlaceMarketOrder, req).Object()
+	return op.PlaceMarketOrderWithContext(context.Background(), req)
+}
+
+func (op *orders) PlaceMarketOrderWithContext(
+	ctx context.Context, req models.MarketOrderRequest,
+) (*models.Order, error) {
+	return op.place(ctx, PlaceMarketOrder, req, describeOrder(req))
 }
 
 func (op *orders) PlaceStopOrder(req models.StopOrderRequest) (*models.Order, error) {
-	return runOperation[models.Order](op.api, http.MethodPost, PlaceStopOrder, req).Object()
+	return op.PlaceStopOrderWithContext(context.Background(), req)
+}
+
+func (op *orders) PlaceStopOrderWithContext(ctx context.Context, req models.StopOrderRequest) (*models.Order, error) {
+	return op.place(ctx, PlaceStopOrder, req, describeOrder(req))
 }
 
 func (op *orders) PlaceStopLimitOrder(req models.StopLimitOrderRequest) (*models.Order, error) {
-	return runOperation[models.Order](op.api, http.MethodPost, PlaceStopLimitOrder, req).Object()
+	return op.PlaceStopLimitOrderWithContext(context.Background(), req)
+}
+
+func (op *orders) PlaceStopLimitOrderWithContext(
+	ctx context.Context, req models.StopLimitOrderRequest,
+) (*models.Order, error) {
+	return op.place(ctx, PlaceStopLimitOrder, req, describeOrder(req))
 }
 
 func (op *orders) CancelOrder(id int64) error {
+	return op.CancelOrderWithContext(context.Background(), id)
+}
+
+func (op *orders) CancelOrderWithContext(ctx context.Context, id int64) error {
 	endpoint := APIEndpoint(fmt.Sprintf("%s/%d", CancelOrder, id))
 
-	return runOperation[models.Empty](op.api, http.MethodDelete, endpoint, nil).err
+	return runOperation[models.Empty](ctx, op.api, http.MethodDelete, endpoint, nil).discard()
 }
 
 func (op *orders) GetPendingOrderByID(id int64) (*models.Order, error) {
+	return op.GetPendingOrderByIDWithContext(context.Background(), id)
+}
+
+func (op *orders) GetPendingOrderByIDWithContext(ctx context.Context, id int64) (*models.Order, error) {
 	endpoint := APIEndpoint(fmt.Sprintf("%s/%d", GetPendingOrderByID, id))
 
-	return runOperation[models.Order](op.api, http.MethodGet, endpoint, nil).Object()
+	return runOperation[models.Order](ctx, op.api, http.MethodGet, endpoint, nil).Object()
 }
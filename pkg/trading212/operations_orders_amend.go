@@ -0,0 +1,173 @@
+package trading212
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+var errAmendUnconfirmed = errors.New("order cancellation requested but not confirmed, no replacement placed")
+
+type operationAmendOrder interface {
+	// AmendOrder operation.
+	// Changes the quantity, prices or time in force of a pending limit, stop or stop-limit order, which the API
+	// does not support: the order is cancelled, the cancellation confirmed through the pending and historical
+	// orders, then a replacement is placed for the unfilled remainder only.
+	// When the replacement fails, the order is placed back with its original terms, see AmendError.
+	AmendOrder(id int64, changes models.OrderChanges) (*AmendResult, error)
+	// AmendOrderWithContext is AmendOrder bound to ctx.
+	AmendOrderWithContext(ctx context.Context, id int64, changes models.OrderChanges) (*AmendResult, error)
+}
+
+// AmendResult is the outcome of an amended order.
+type AmendResult struct {
+	// Original order, in its final state once cancelled.
+	Original *models.Order
+	// Fills of the original order, made before its cancellation.
+	Fills []*models.OrderFill
+	// Filled quantity of the original order, positive.
+	Filled models.Quantity
+	// Replacement order for the unfilled remainder, nil when nothing remained.
+	Replacement *models.Order
+}
+
+// AmendError is returned with the AmendResult when the original order was cancelled,
+// but its replacement could not be placed.
+// The order is not placed back when the outcome of the replacement is unknown, as it may exist.
+type AmendError struct {
+	// Err placing the replacement.
+	Err error
+	// Restored order, placed back with the original terms for the unfilled remainder, if any.
+	Restored *models.Order
+	// RestoreErr met while placing the order back, if any.
+	RestoreErr error
+}
+
+// Error implements error.
+func (e *AmendError) Error() string {
+	msg := fmt.Sprintf("order cancelled, replacement failed: %v", e.Err)
+
+	switch {
+	case e.RestoreErr != nil:
+		msg += fmt.Sprintf("; restoring failed: %v", e.RestoreErr)
+	case e.Restored != nil:
+		msg += fmt.Sprintf("; restored as order %d", e.Restored.ID)
+	}
+
+	return msg
+}
+
+// Unwrap returns the underlying errors.
+func (e *AmendError) Unwrap() []error {
+	return []error{e.Err, e.RestoreErr}
+}
+
+func (op *orders) AmendOrder(id int64, changes models.OrderChanges) (*AmendResult, error) {
+	return op.AmendOrderWithContext(context.Background(), id, changes)
+}
+
+func (op *orders) AmendOrderWithContext(
+	ctx context.Context, id int64, changes models.OrderChanges,
+) (*AmendResult, error) {
+	original, err := op.GetPendingOrderByIDWithContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// checked before cancelling, so that invalid changes leave the order untouched
+	_, err = changes.Replacement(original, changes.TotalQuantity(original))
+	if err != nil {
+		return nil, errors.Join(errInvalidRequest, err)
+	}
+
+	// not found once filled or cancelled meanwhile, the final state tells
+	err = op.CancelOrderWithContext(ctx, id)
+	if err != nil && !IsNotFound(err) {
+		return nil, err
+	}
+
+	final, err := op.tracker.WaitForTerminalOf(ctx, original)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errAmendUnconfirmed, err)
+	}
+
+	result := &AmendResult{
+		Original: final.Order, Fills: final.Fills, Filled: filledQuantity(final), Replacement: nil,
+	}
+
+	remaining := changes.TotalQuantity(original).Sub(result.Filled)
+	if remaining.Sign() <= 0 {
+		return result, nil
+	}
+
+	replacement, err := changes.Replacement(original, remaining)
+	if err == nil {
+		result.Replacement, err = op.placeRequest(ctx, replacement)
+	}
+
+	if err != nil {
+		return result, op.restore(context.WithoutCancel(ctx), original, result.Filled, err)
+	}
+
+	return result, nil
+}
+
+// restore places the original order back for its unfilled remainder, after its replacement failed.
+func (op *orders) restore(
+	ctx context.Context, original *models.Order, filled models.Quantity, placeErr error,
+) error {
+	amendErr := &AmendError{Err: placeErr, Restored: nil, RestoreErr: nil}
+
+	var outcomeUnknown *OrderOutcomeUnknownError
+	if errors.As(placeErr, &outcomeUnknown) {
+		return amendErr
+	}
+
+	remaining := original.Quantity.Abs().Sub(filled)
+	if remaining.Sign() <= 0 {
+		return amendErr
+	}
+
+	req, err := models.OrderChanges{Quantity: nil, LimitPrice: nil, StopPrice: nil, TimeInForce: ""}.
+		Replacement(original, remaining)
+	if err == nil {
+		amendErr.Restored, err = op.placeRequest(ctx, req)
+	}
+
+	amendErr.RestoreErr = err
+
+	return amendErr
+}
+
+// placeRequest places any order request.
+func (op *orders) placeRequest(ctx context.Context, req any) (*models.Order, error) {
+	switch req := req.(type) {
+	case models.LimitOrderRequest:
+		return op.PlaceLimitOrderWithContext(ctx, req)
+	case models.MarketOrderRequest:
+		return op.PlaceMarketOrderWithContext(ctx, req)
+	case models.StopOrderRequest:
+		return op.PlaceStopOrderWithContext(ctx, req)
+	case models.StopLimitOrderRequest:
+		return op.PlaceStopLimitOrderWithContext(ctx, req)
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnsupportedOrder, req)
+	}
+}
+
+// filledQuantity of a done order, from its fills when found.
+func filledQuantity(final *TrackedOrder) models.Quantity {
+	if len(final.Fills) == 0 {
+		return final.Order.FilledQuantity.Abs()
+	}
+
+	var filled models.Quantity
+
+	for _, fill := range final.Fills {
+		filled = filled.Add(fill.Fill.Quantity.Abs())
+	}
+
+	return filled
+}
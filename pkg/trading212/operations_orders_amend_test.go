@@ -0,0 +1,250 @@
+package trading212
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+// newAmendMockAPI serves the order 42 while pending, then its final state from the history,
+// and answers the limit order placements with the statuses in sequence. It returns the placed bodies.
+// The history is only served to the searches narrowed to the ticker of the order.
+func newAmendMockAPI(t *testing.T, order string, history string, placeStatuses []int) (*API, func() []string) {
+	t.Helper()
+
+	var (
+		mutex     sync.Mutex
+		cancelled bool
+		placed    []string
+	)
+
+	mockAPI := newRoutesMockAPI(t, map[APIEndpoint]http.HandlerFunc{
+		http.MethodGet + " " + GetPendingOrderByID + "/42": func(w http.ResponseWriter, _ *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if cancelled {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			_, _ = fmt.Fprint(w, order)
+		},
+		http.MethodDelete + " " + CancelOrder + "/42": func(http.ResponseWriter, *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			cancelled = true
+		},
+		http.MethodGet + " " + GetHistoricalOrders: func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("ticker") != "AAPL_US_EQ" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			_, _ = fmt.Fprint(w, history)
+		},
+		http.MethodPost + " " + PlaceLimitOrder: func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			body, _ := io.ReadAll(r.Body)
+			placed = append(placed, string(body))
+
+			status := placeStatuses[min(len(placed), len(placeStatuses))-1]
+			w.WriteHeader(status)
+
+			if status == http.StatusOK {
+				_, _ = fmt.Fprintf(w, `{"id": %d, "ticker": "AAPL_US_EQ", "status": "NEW"}`, 42+len(placed))
+			}
+		},
+	})
+
+	tracker := mockAPI.Orders.(*orders).tracker //nolint:forcetypeassert // set by NewAPI
+	tracker.PollInterval = time.Millisecond
+	tracker.HistoryInterval = time.Millisecond
+
+	return mockAPI, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return placed
+	}
+}
+
+func Test_Orders_AmendOrder(t *testing.T) {
+	t.Parallel()
+
+	const (
+		createdAt = `"createdAt": "2024-01-02T15:00:00Z"`
+		order     = `{"id": 42, "ticker": "AAPL_US_EQ", "type": "LIMIT", "side": %q, "quantity": %d, ` +
+			`"limitPrice": 150, "timeInForce": "DAY", "status": %q, ` + createdAt + `}`
+		fill = `{"order": ` + order + `, "fill": {"id": %d, "quantity": %d, "price": 150}}`
+	)
+
+	history := func(side string, quantity int, status string, fills ...int) string {
+		if len(fills) == 0 {
+			fills = []int{0}
+		}
+
+		items := []string{}
+		for i, filled := range fills {
+			items = append(items, fmt.Sprintf(fill, side, quantity, status, i+1, filled))
+		}
+
+		return fmt.Sprintf(`{"items": [%s], "nextPagePath": null}`, strings.Join(items, ", "))
+	}
+
+	price := models.DecimalFromInt(155)
+	quantity := models.DecimalFromInt(12)
+	negative := models.DecimalFromInt(-1)
+
+	tests := []struct {
+		name            string
+		side            string
+		quantity        int
+		history         string
+		changes         models.OrderChanges
+		placeStatuses   []int
+		wantFilled      string
+		wantReplacement bool
+		wantPlaced      []string
+		wantErr         error
+	}{
+		{
+			name:            "new price",
+			side:            "BUY",
+			quantity:        10,
+			history:         history("BUY", 10, "CANCELLED"),
+			changes:         models.OrderChanges{Quantity: nil, LimitPrice: &price, StopPrice: nil, TimeInForce: ""},
+			placeStatuses:   []int{http.StatusOK},
+			wantFilled:      "0",
+			wantReplacement: true,
+			wantPlaced: []string{
+				`{"ticker":"AAPL_US_EQ","quantity":10,"limitPrice":155,"timeInForce":"DAY"}`,
+			},
+			wantErr: nil,
+		},
+		{
+			name:     "partially filled sell",
+			side:     "SELL",
+			quantity: -10,
+			history:  history("SELL", -10, "CANCELLED", -2, -1),
+			changes: models.OrderChanges{
+				Quantity: &quantity, LimitPrice: nil, StopPrice: nil, TimeInForce: models.TimeInForceGoodTillCancel,
+			},
+			placeStatuses:   []int{http.StatusOK},
+			wantFilled:      "3",
+			wantReplacement: true,
+			wantPlaced: []string{
+				`{"ticker":"AAPL_US_EQ","quantity":-9,"limitPrice":150,"timeInForce":"GOOD_TILL_CANCEL"}`,
+			},
+			wantErr: nil,
+		},
+		{
+			name:            "filled meanwhile",
+			side:            "BUY",
+			quantity:        10,
+			history:         history("BUY", 10, "FILLED", 10),
+			changes:         models.OrderChanges{Quantity: nil, LimitPrice: &price, StopPrice: nil, TimeInForce: ""},
+			placeStatuses:   []int{http.StatusOK},
+			wantFilled:      "10",
+			wantReplacement: false,
+			wantPlaced:      nil,
+			wantErr:         nil,
+		},
+		{
+			name:            "replacement refused",
+			side:            "BUY",
+			quantity:        10,
+			history:         history("BUY", 10, "CANCELLED", 4),
+			changes:         models.OrderChanges{Quantity: nil, LimitPrice: &price, StopPrice: nil, TimeInForce: ""},
+			placeStatuses:   []int{http.StatusBadRequest, http.StatusOK},
+			wantFilled:      "4",
+			wantReplacement: false,
+			wantPlaced: []string{
+				`{"ticker":"AAPL_US_EQ","quantity":6,"limitPrice":155,"timeInForce":"DAY"}`,
+				`{"ticker":"AAPL_US_EQ","quantity":6,"limitPrice":150,"timeInForce":"DAY"}`,
+			},
+			wantErr: errNon200,
+		},
+		{
+			name:            "invalid changes",
+			side:            "BUY",
+			quantity:        10,
+			history:         history("BUY", 10, "CANCELLED"),
+			changes:         models.OrderChanges{Quantity: &negative, LimitPrice: nil, StopPrice: &price, TimeInForce: ""},
+			placeStatuses:   []int{http.StatusOK},
+			wantFilled:      "",
+			wantReplacement: false,
+			wantPlaced:      nil,
+			wantErr:         errInvalidRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pending := fmt.Sprintf(order, tt.side, tt.quantity, "NEW")
+			mockAPI, placed := newAmendMockAPI(t, pending, tt.history, tt.placeStatuses)
+
+			result, err := mockAPI.Orders.AmendOrder(42, tt.changes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AmendOrder() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if fmt.Sprint(placed()) != fmt.Sprint(tt.wantPlaced) {
+				t.Errorf("AmendOrder() placed %v, want %v", placed(), tt.wantPlaced)
+			}
+
+			if tt.wantFilled == "" {
+				if result != nil {
+					t.Errorf("AmendOrder() = %v, want nil", result)
+				}
+
+				return
+			}
+
+			if result.Filled.String() != tt.wantFilled || (result.Replacement != nil) != tt.wantReplacement {
+				t.Errorf("AmendOrder() filled %v, replacement %v, want %s, %v",
+					result.Filled, result.Replacement, tt.wantFilled, tt.wantReplacement)
+			}
+
+			var amendErr *AmendError
+			if errors.As(err, &amendErr) && (amendErr.Restored == nil || amendErr.Restored.ID != 44) {
+				t.Errorf("AmendOrder() restored %v, want order 44", amendErr.Restored)
+			}
+		})
+	}
+}
+
+func Test_Orders_AmendOrder_Unconfirmed(t *testing.T) {
+	t.Parallel()
+
+	mockAPI, placed := newAmendMockAPI(
+		t, `{"id": 42, "ticker": "AAPL_US_EQ", "type": "LIMIT", "quantity": 1, "limitPrice": 150}`,
+		`{"items": [], "nextPagePath": null}`, []int{http.StatusOK},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	price := models.DecimalFromInt(155)
+
+	result, err := mockAPI.Orders.AmendOrderWithContext(
+		ctx, 42, models.OrderChanges{Quantity: nil, LimitPrice: &price, StopPrice: nil, TimeInForce: ""},
+	)
+	if result != nil || !errors.Is(err, errAmendUnconfirmed) || len(placed()) > 0 {
+		t.Errorf("AmendOrderWithContext() = %v, %v, placed %v, want %v", result, err, placed(), errAmendUnconfirmed)
+	}
+}
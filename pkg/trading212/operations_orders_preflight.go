@@ -0,0 +1,334 @@
+package trading212
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+var (
+	errPreflight        = errors.New("order failed the pre-flight checks")
+	errUnsupportedOrder = errors.New("unsupported order request")
+)
+
+type operationPreflight interface {
+	// Preflight operation.
+	// Checks an order request (models.LimitOrderRequest, models.MarketOrderRequest, models.StopOrderRequest
+	// or models.StopLimitOrderRequest) against the instruments, the positions and the account cash,
+	// without placing it. An empty result means no violation was found, not that the order will be accepted.
+	// See WithOrderPreflight to run it before every placement.
+	Preflight(req any) ([]Violation, error)
+	// PreflightWithContext is Preflight bound to ctx.
+	PreflightWithContext(ctx context.Context, req any) ([]Violation, error)
+}
+
+// ViolationCode identifies a failed pre-flight check.
+type ViolationCode string
+
+const (
+	// ViolationQuantity is a zero quantity.
+	ViolationQuantity ViolationCode = "QUANTITY"
+	// ViolationPrice is a missing limit or stop price, or a limit price on the wrong side of the stop price.
+	ViolationPrice ViolationCode = "PRICE"
+	// ViolationUnknownTicker is a ticker missing from the available instruments.
+	ViolationUnknownTicker ViolationCode = "UNKNOWN_TICKER"
+	// ViolationMaxOpenQuantity is a quantity above the instrument maximum open quantity.
+	ViolationMaxOpenQuantity ViolationCode = "MAX_OPEN_QUANTITY"
+	// ViolationExtendedHours is an extended hours order on an instrument not supporting them.
+	ViolationExtendedHours ViolationCode = "EXTENDED_HOURS"
+	// ViolationInsufficientPosition is a sell above the position quantity available for trading.
+	ViolationInsufficientPosition ViolationCode = "INSUFFICIENT_POSITION"
+	// ViolationStopPrice is a stop price already reached by the current price.
+	ViolationStopPrice ViolationCode = "STOP_PRICE"
+	// ViolationInsufficientFunds is a buy costing more than the cash available to trade.
+	ViolationInsufficientFunds ViolationCode = "INSUFFICIENT_FUNDS"
+)
+
+// Violation is a failed pre-flight check.
+type Violation struct {
+	// Code of the failed check.
+	Code ViolationCode
+	// Field of the order request at fault, as named in json.
+	Field string
+	// Message describing the violation.
+	Message string
+}
+
+// String implements fmt.Stringer.
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s: %s", v.Code, v.Field, v.Message)
+}
+
+// PreflightError is returned, when WithOrderPreflight is set, for the orders failing the pre-flight checks.
+// The order was not sent.
+type PreflightError struct {
+	// Violations found.
+	Violations []Violation
+}
+
+// Error implements error.
+func (e *PreflightError) Error() string {
+	violations := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		violations = append(violations, violation.String())
+	}
+
+	return fmt.Sprintf("%v: %s", errPreflight, strings.Join(violations, "; "))
+}
+
+// Unwrap returns the sentinel error.
+func (e *PreflightError) Unwrap() error {
+	return errPreflight
+}
+
+func (op *orders) Preflight(req any) ([]Violation, error) {
+	return op.PreflightWithContext(context.Background(), req)
+}
+
+func (op *orders) PreflightWithContext(ctx context.Context, req any) ([]Violation, error) {
+	match := describeOrder(req)
+	if match.orderType == "" {
+		return nil, fmt.Errorf("%w: %T", errUnsupportedOrder, req)
+	}
+
+	return op.preflight(ctx, match)
+}
+
+// preflight runs the checks, the checks needing the instrument are skipped when the order
+// is malformed, and those needing the account when the instrument is unknown.
+func (op *orders) preflight(ctx context.Context, match orderMatch) ([]Violation, error) {
+	violations := checkOrderShape(match)
+	if len(violations) > 0 {
+		return violations, nil
+	}
+
+	instrument, err := op.findInstrument(ctx, match.ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	if instrument == nil {
+		return []Violation{{
+			Code:    ViolationUnknownTicker,
+			Field:   "ticker",
+			Message: fmt.Sprintf("%q is not an available instrument", match.ticker),
+		}}, nil
+	}
+
+	violations = checkInstrument(match, instrument)
+
+	position, err := op.findPosition(ctx, match.ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	violations = append(violations, checkPosition(match, position)...)
+
+	if match.quantity.Sign() < 0 {
+		return violations, nil
+	}
+
+	funds, err := op.checkFunds(ctx, match, instrument, position)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(violations, funds...), nil
+}
+
+// checkOrderShape checks the order on its own.
+func checkOrderShape(match orderMatch) []Violation {
+	var violations []Violation
+
+	if match.quantity.IsZero() {
+		violations = append(violations, Violation{
+			Code:  ViolationQuantity,
+			Field: "quantity",
+			Message: fmt.Sprintf("%v is not a valid quantity, use a positive quantity to buy and a negative one to sell",
+				match.quantity),
+		})
+	}
+
+	needsLimit := match.orderType == models.OrderTypeLimit || match.orderType == models.OrderTypeStopLimit
+	if needsLimit && match.limitPrice.Sign() <= 0 {
+		violations = append(violations, Violation{
+			Code:    ViolationPrice,
+			Field:   "limitPrice",
+			Message: fmt.Sprintf("%v is not a valid limit price", match.limitPrice),
+		})
+	}
+
+	needsStop := match.orderType == models.OrderTypeStop || match.orderType == models.OrderTypeStopLimit
+	if needsStop && match.stopPrice.Sign() <= 0 {
+		violations = append(violations, Violation{
+			Code:    ViolationPrice,
+			Field:   "stopPrice",
+			Message: fmt.Sprintf("%v is not a valid stop price", match.stopPrice),
+		})
+	}
+
+	if len(violations) > 0 || match.orderType != models.OrderTypeStopLimit {
+		return violations
+	}
+
+	if match.quantity.Sign() > 0 && match.limitPrice.Cmp(match.stopPrice) < 0 {
+		violations = append(violations, Violation{
+			Code:    ViolationPrice,
+			Field:   "limitPrice",
+			Message: fmt.Sprintf("buy limit price %v is below the stop price %v", match.limitPrice, match.stopPrice),
+		})
+	}
+
+	if match.quantity.Sign() < 0 && match.limitPrice.Cmp(match.stopPrice) > 0 {
+		violations = append(violations, Violation{
+			Code:    ViolationPrice,
+			Field:   "limitPrice",
+			Message: fmt.Sprintf("sell limit price %v is above the stop price %v", match.limitPrice, match.stopPrice),
+		})
+	}
+
+	return violations
+}
+
+// checkInstrument checks the order against the instrument trading conditions.
+func checkInstrument(match orderMatch, instrument *models.Instrument) []Violation {
+	var violations []Violation
+
+	if instrument.MaxOpenQuantity.Sign() > 0 && match.quantity.Abs().Cmp(instrument.MaxOpenQuantity) > 0 {
+		violations = append(violations, Violation{
+			Code:  ViolationMaxOpenQuantity,
+			Field: "quantity",
+			Message: fmt.Sprintf("%v exceeds the maximum open quantity %v of %s",
+				match.quantity.Abs(), instrument.MaxOpenQuantity, instrument.Ticker),
+		})
+	}
+
+	if match.extendedHours && !instrument.ExtendedHours {
+		violations = append(violations, Violation{
+			Code:    ViolationExtendedHours,
+			Field:   "extendedHours",
+			Message: instrument.Ticker.String() + " does not trade in extended hours",
+		})
+	}
+
+	return violations
+}
+
+// checkPosition checks the order against the position held, nil when none.
+func checkPosition(match orderMatch, position *models.Position) []Violation {
+	var violations []Violation
+
+	if match.quantity.Sign() < 0 {
+		var available models.Quantity
+		if position != nil {
+			available = position.QuantityAvailableForTrading
+		}
+
+		if match.quantity.Neg().Cmp(available) > 0 {
+			violations = append(violations, Violation{
+				Code:    ViolationInsufficientPosition,
+				Field:   "quantity",
+				Message: fmt.Sprintf("selling %v but only %v available for trading", match.quantity.Neg(), available),
+			})
+		}
+	}
+
+	if match.stopPrice.IsZero() || position == nil || position.CurrentPrice.Sign() <= 0 {
+		return violations
+	}
+
+	current := position.CurrentPrice.Amount
+
+	if match.quantity.Sign() > 0 && match.stopPrice.Cmp(current) <= 0 {
+		violations = append(violations, Violation{
+			Code:  ViolationStopPrice,
+			Field: "stopPrice",
+			Message: fmt.Sprintf("buy stop price %v is not above the current price %v",
+				match.stopPrice, current),
+		})
+	}
+
+	if match.quantity.Sign() < 0 && match.stopPrice.Cmp(current) >= 0 {
+		violations = append(violations, Violation{
+			Code:  ViolationStopPrice,
+			Field: "stopPrice",
+			Message: fmt.Sprintf("sell stop price %v is not below the current price %v",
+				match.stopPrice, current),
+		})
+	}
+
+	return violations
+}
+
+// checkFunds checks a buy against the cash available to trade. The check is skipped when
+// the price is unknown, or when the instrument is not traded in the account currency.
+func (op *orders) checkFunds(
+	ctx context.Context, match orderMatch, instrument *models.Instrument, position *models.Position,
+) ([]Violation, error) {
+	price := match.limitPrice
+	if price.IsZero() {
+		price = match.stopPrice
+	}
+
+	if price.IsZero() && position != nil {
+		price = position.CurrentPrice.Amount
+	}
+
+	if price.Sign() <= 0 {
+		return nil, nil
+	}
+
+	summary, err := runOperation[models.AccountSummary](ctx, op.api, http.MethodGet, GetAccountSummary, nil).Object()
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.Currency != instrument.CurrencyCode {
+		return nil, nil
+	}
+
+	cost := match.quantity.Mul(price)
+	if cost.Cmp(summary.Cash.AvailableToTrade.Amount) <= 0 {
+		return nil, nil
+	}
+
+	return []Violation{{
+		Code:  ViolationInsufficientFunds,
+		Field: "quantity",
+		Message: fmt.Sprintf("buying for %v %s but only %v available to trade",
+			cost, summary.Currency, summary.Cash.AvailableToTrade),
+	}}, nil
+}
+
+func (op *orders) findInstrument(ctx context.Context, ticker models.Ticker) (*models.Instrument, error) {
+	for instrument, err := range op.instruments.availableInstruments(ctx) {
+		if err != nil {
+			return nil, err
+		}
+
+		if instrument.Ticker == ticker {
+			return instrument, nil
+		}
+	}
+
+	return nil, nil //nolint:nilnil // an unknown ticker is a violation, not an error.
+}
+
+func (op *orders) findPosition(ctx context.Context, ticker models.Ticker) (*models.Position, error) {
+	positions, err := runOperation[models.Position](ctx, op.api, http.MethodGet, GetAllPositions, nil).Items()
+	if err != nil {
+		return nil, err
+	}
+
+	for position := range positions {
+		if position.Instrument.Ticker == ticker {
+			return position, nil
+		}
+	}
+
+	return nil, nil //nolint:nilnil // no position is a valid state.
+}
@@ -0,0 +1,239 @@
+package trading212
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+const (
+	preflightInstruments = `[
+		{"ticker": "AAPL_US_EQ", "type": "STOCK", "currencyCode": "USD", "maxOpenQuantity": 100, "extendedHours": true},
+		{"ticker": "VOD_LSE_EQ", "type": "STOCK", "currencyCode": "GBX", "maxOpenQuantity": 0, "extendedHours": false}
+	]`
+	preflightPositions = `[
+		{"instrument": {"ticker": "AAPL_US_EQ"}, "quantity": 10, "quantityAvailableForTrading": 8, "currentPrice": 150}
+	]`
+	preflightAccount = `{"currency": "USD", "cash": {"availableToTrade": 1000}}`
+)
+
+func newPreflightMockAPI(t *testing.T, enforce bool) (*API, *atomic.Int32) {
+	t.Helper()
+
+	var placed atomic.Int32
+
+	place := func(w http.ResponseWriter, _ *http.Request) {
+		placed.Add(1)
+
+		_, _ = fmt.Fprint(w, `{"id": 42, "ticker": "AAPL_US_EQ"}`)
+	}
+
+	mockAPI := newRoutesMockAPI(t, map[APIEndpoint]http.HandlerFunc{
+		http.MethodGet + " " + GetAllAvailableInstruments: serveBody(preflightInstruments),
+		http.MethodGet + " " + GetAllPositions:            serveBody(preflightPositions),
+		http.MethodGet + " " + GetAccountSummary:          serveBody(preflightAccount),
+		http.MethodPost + " " + PlaceLimitOrder:           place,
+		http.MethodPost + " " + PlaceMarketOrder:          place,
+	})
+
+	mockAPI.Orders.(*orders).enforcePreflight = enforce
+
+	return mockAPI, &placed
+}
+
+func Test_Orders_Preflight(t *testing.T) {
+	t.Parallel()
+
+	limit := func(ticker models.Ticker, quantity, limitPrice float64) models.LimitOrderRequest {
+		req := models.LimitOrderRequest{}
+		req.Ticker = ticker
+		req.Quantity = models.MustDecimal(quantity)
+		req.LimitPrice = models.MustDecimal(limitPrice)
+
+		return req
+	}
+	market := func(ticker models.Ticker, quantity float64, extendedHours bool) models.MarketOrderRequest {
+		req := models.MarketOrderRequest{}
+		req.Ticker = ticker
+		req.Quantity = models.MustDecimal(quantity)
+		req.ExtendedHours = extendedHours
+
+		return req
+	}
+	stop := func(quantity, stopPrice float64) models.StopOrderRequest {
+		req := models.StopOrderRequest{}
+		req.Ticker = "AAPL_US_EQ"
+		req.Quantity = models.MustDecimal(quantity)
+		req.StopPrice = models.MustDecimal(stopPrice)
+
+		return req
+	}
+	stopLimit := func(quantity, stopPrice, limitPrice float64) models.StopLimitOrderRequest {
+		req := models.StopLimitOrderRequest{}
+		req.Ticker = "AAPL_US_EQ"
+		req.Quantity = models.MustDecimal(quantity)
+		req.StopPrice = models.MustDecimal(stopPrice)
+		req.LimitPrice = models.MustDecimal(limitPrice)
+
+		return req
+	}
+
+	tests := []struct {
+		name string
+		req  any
+		want []ViolationCode
+	}{
+		{name: "valid buy", req: limit("AAPL_US_EQ", 5, 150), want: nil},
+		{name: "valid sell", req: limit("AAPL_US_EQ", -8, 160), want: nil},
+		{name: "zero quantity", req: limit("AAPL_US_EQ", 0, 150), want: []ViolationCode{ViolationQuantity}},
+		{name: "zero market quantity", req: market("AAPL_US_EQ", 0, false), want: []ViolationCode{ViolationQuantity}},
+		{name: "missing limit price", req: limit("AAPL_US_EQ", 1, 0), want: []ViolationCode{ViolationPrice}},
+		{name: "unknown ticker", req: limit("NOPE_US_EQ", 1, 150), want: []ViolationCode{ViolationUnknownTicker}},
+		{
+			name: "max open quantity", req: limit("AAPL_US_EQ", -101, 150),
+			want: []ViolationCode{ViolationMaxOpenQuantity, ViolationInsufficientPosition},
+		},
+		{
+			name: "extended hours", req: market("VOD_LSE_EQ", 1, true),
+			want: []ViolationCode{ViolationExtendedHours},
+		},
+		{name: "extended hours supported", req: market("AAPL_US_EQ", 1, true), want: nil},
+		{
+			name: "sell without position", req: market("VOD_LSE_EQ", -1, false),
+			want: []ViolationCode{ViolationInsufficientPosition},
+		},
+		{
+			name: "sell above available", req: market("AAPL_US_EQ", -9, false),
+			want: []ViolationCode{ViolationInsufficientPosition},
+		},
+		{
+			name: "insufficient funds", req: limit("AAPL_US_EQ", 10, 150),
+			want: []ViolationCode{ViolationInsufficientFunds},
+		},
+		{
+			name: "insufficient funds at market", req: market("AAPL_US_EQ", 7, false),
+			want: []ViolationCode{ViolationInsufficientFunds},
+		},
+		{name: "other currency skips funds", req: limit("VOD_LSE_EQ", 1000, 150), want: nil},
+		{name: "buy stop above price", req: stop(1, 160), want: nil},
+		{name: "buy stop below price", req: stop(1, 140), want: []ViolationCode{ViolationStopPrice}},
+		{name: "sell stop below price", req: stop(-1, 140), want: nil},
+		{name: "sell stop above price", req: stop(-1, 160), want: []ViolationCode{ViolationStopPrice}},
+		{name: "buy stop limit", req: stopLimit(1, 160, 161), want: nil},
+		{name: "buy stop limit below stop", req: stopLimit(1, 160, 159), want: []ViolationCode{ViolationPrice}},
+		{name: "sell stop limit above stop", req: stopLimit(-1, 140, 141), want: []ViolationCode{ViolationPrice}},
+	}
+
+	mockAPI, placed := newPreflightMockAPI(t, false)
+	t.Cleanup(func() {
+		if placed.Load() != 0 {
+			t.Errorf("Preflight() placed %d orders", placed.Load())
+		}
+	})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			violations, err := mockAPI.Orders.Preflight(test.req)
+			if err != nil {
+				t.Fatalf("Preflight() error = %v", err)
+			}
+
+			codes := make([]ViolationCode, 0, len(violations))
+			for _, violation := range violations {
+				codes = append(codes, violation.Code)
+			}
+
+			if fmt.Sprint(codes) != fmt.Sprint(append([]ViolationCode{}, test.want...)) {
+				t.Errorf("Preflight() = %v, want %v", violations, test.want)
+			}
+		})
+	}
+}
+
+func Test_Orders_Preflight_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	mockAPI, _ := newPreflightMockAPI(t, false)
+
+	_, err := mockAPI.Orders.Preflight(models.Order{})
+	if !errors.Is(err, errUnsupportedOrder) {
+		t.Errorf("Preflight() error = %v, want %v", err, errUnsupportedOrder)
+	}
+}
+
+func Test_Orders_Preflight_BrokenInstruments(t *testing.T) {
+	t.Parallel()
+
+	mockAPI := newRoutesMockAPI(t, map[APIEndpoint]http.HandlerFunc{
+		http.MethodGet + " " + GetAllAvailableInstruments: serveBody(`[{"ticker": "MSFT_US_EQ"}, {"ticker": `),
+	})
+
+	req := models.LimitOrderRequest{}
+	req.Ticker, req.Quantity, req.LimitPrice = "AAPL_US_EQ", models.MustDecimal(1), models.MustDecimal(150)
+
+	violations, err := mockAPI.Orders.Preflight(req)
+	if err == nil || violations != nil {
+		t.Errorf("Preflight() = %v, %v, want the error of the instruments", violations, err)
+	}
+}
+
+func Test_Orders_Preflight_Enforced(t *testing.T) {
+	t.Parallel()
+
+	req := models.LimitOrderRequest{}
+	req.Ticker = "NOPE_US_EQ"
+	req.Quantity = models.MustDecimal(1)
+	req.LimitPrice = models.MustDecimal(150)
+
+	t.Run("not enforced", func(t *testing.T) {
+		t.Parallel()
+
+		mockAPI, placed := newPreflightMockAPI(t, false)
+
+		if _, err := mockAPI.Orders.PlaceLimitOrder(req); err != nil {
+			t.Fatalf("PlaceLimitOrder() error = %v", err)
+		}
+
+		if placed.Load() != 1 {
+			t.Errorf("PlaceLimitOrder() placed %d orders, want 1", placed.Load())
+		}
+	})
+
+	t.Run("enforced", func(t *testing.T) {
+		t.Parallel()
+
+		mockAPI, placed := newPreflightMockAPI(t, true)
+
+		_, err := mockAPI.Orders.PlaceLimitOrder(req)
+
+		var preflightErr *PreflightError
+		if !errors.As(err, &preflightErr) || !errors.Is(err, errPreflight) {
+			t.Fatalf("PlaceLimitOrder() error = %v, want *PreflightError", err)
+		}
+
+		if len(preflightErr.Violations) != 1 || preflightErr.Violations[0].Code != ViolationUnknownTicker {
+			t.Errorf("PlaceLimitOrder() violations = %v", preflightErr.Violations)
+		}
+
+		if placed.Load() != 0 {
+			t.Errorf("PlaceLimitOrder() placed %d orders, want 0", placed.Load())
+		}
+
+		req := req
+		req.Ticker = "AAPL_US_EQ"
+
+		if _, err := mockAPI.Orders.PlaceLimitOrder(req); err != nil {
+			t.Fatalf("PlaceLimitOrder() error = %v", err)
+		}
+
+		if placed.Load() != 1 {
+			t.Errorf("PlaceLimitOrder() placed %d orders, want 1", placed.Load())
+		}
+	})
+}
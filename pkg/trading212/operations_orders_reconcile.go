@@ -0,0 +1,230 @@
+package trading212
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+const (
+	// reconcileClockSkew tolerated between the local clock and the order creation date.
+	reconcileClockSkew = 5 * time.Second
+	// reconcileTimeout bounds the search, which outlives the context of the failed placement.
+	reconcileTimeout = 30 * time.Second
+	// reconcileClaimRetention keeps the claimed orders while a later placement could still match them.
+	reconcileClaimRetention = time.Minute
+)
+
+// OrderOutcomeUnknownError is returned when an order placement failed in a way that does not tell
+// whether the order was accepted (timeout, server or transport error), and no matching order
+// could be found in the pending and historical orders afterward.
+// The order may still show up later, check before placing it again.
+type OrderOutcomeUnknownError struct {
+	// Ticker of the order.
+	Ticker models.Ticker
+	// Quantity of the order.
+	Quantity models.Quantity
+	// SentAt local time at which the order placement was sent.
+	SentAt time.Time
+	// Err that made the outcome ambiguous.
+	Err error
+	// ReconcileErr met while searching for the order, if any.
+	ReconcileErr error
+}
+
+// Error implements error.
+func (e *OrderOutcomeUnknownError) Error() string {
+	msg := fmt.Sprintf(
+		"order %s x %v sent at %s: outcome unknown: %v", e.Ticker, e.Quantity, e.SentAt.Format(time.RFC3339), e.Err,
+	)
+	if e.ReconcileErr != nil {
+		msg += fmt.Sprintf("; reconciliation failed: %v", e.ReconcileErr)
+	}
+
+	return msg
+}
+
+// Unwrap returns the underlying errors.
+func (e *OrderOutcomeUnknownError) Unwrap() []error {
+	return []error{e.Err, e.ReconcileErr}
+}
+
+// orderMatch describes a placed order, to check it and find it back.
+type orderMatch struct {
+	ticker        models.Ticker
+	quantity      models.Quantity
+	orderType     models.OrderType
+	limitPrice    models.Decimal
+	stopPrice     models.Decimal
+	extendedHours bool
+}
+
+// describeOrder returns the orderMatch of an order request, with an empty orderType for
+// unsupported requests.
+func describeOrder(req any) orderMatch {
+	var match orderMatch
+
+	switch req := req.(type) {
+	case models.LimitOrderRequest:
+		match.ticker, match.quantity, match.orderType = req.Ticker, req.Quantity, models.OrderTypeLimit
+		match.limitPrice = req.LimitPrice
+	case models.MarketOrderRequest:
+		match.ticker, match.quantity, match.orderType = req.Ticker, req.Quantity, models.OrderTypeMarket
+		match.extendedHours = req.ExtendedHours
+	case models.StopOrderRequest:
+		match.ticker, match.quantity, match.orderType = req.Ticker, req.Quantity, models.OrderTypeStop
+		match.stopPrice = req.StopPrice
+	case models.StopLimitOrderRequest:
+		match.ticker, match.quantity, match.orderType = req.Ticker, req.Quantity, models.OrderTypeStopLimit
+		match.limitPrice, match.stopPrice = req.LimitPrice, req.StopPrice
+	}
+
+	return match
+}
+
+// matches reports whether the order matches, created between the placement and its failure.
+func (match orderMatch) matches(order *models.Order, sentAt, failedAt time.Time) bool {
+	if order == nil || order.Ticker != match.ticker || order.Type != match.orderType {
+		return false
+	}
+
+	if !order.Quantity.Abs().Equal(match.quantity.Abs()) {
+		return false
+	}
+
+	if order.Side != "" && (order.Side == models.OrderSideSell) != (match.quantity.Sign() < 0) {
+		return false
+	}
+
+	if !match.limitPrice.IsZero() && !order.LimitPrice.Amount.Equal(match.limitPrice) {
+		return false
+	}
+
+	if !match.stopPrice.IsZero() && !order.StopPrice.Amount.Equal(match.stopPrice) {
+		return false
+	}
+
+	return !order.CreatedAt.Before(sentAt.Add(-reconcileClockSkew)) &&
+		!order.CreatedAt.After(failedAt.Add(reconcileClockSkew))
+}
+
+// orderClaims are the orders already returned by a placement, so an identical order placed
+// shortly before is not mistaken for the one of an ambiguous placement.
+type orderClaims struct {
+	mutex  sync.Mutex
+	claims map[uint]time.Time
+}
+
+func newOrderClaims() *orderClaims {
+	return &orderClaims{mutex: sync.Mutex{}, claims: map[uint]time.Time{}}
+}
+
+// claim the order, returning false when it was already claimed.
+func (claims *orderClaims) claim(order *models.Order) bool {
+	claims.mutex.Lock()
+	defer claims.mutex.Unlock()
+
+	now := time.Now()
+	for id, claimedAt := range claims.claims {
+		if now.Sub(claimedAt) > reconcileClaimRetention {
+			delete(claims.claims, id)
+		}
+	}
+
+	if _, claimed := claims.claims[order.ID]; claimed {
+		return false
+	}
+
+	claims.claims[order.ID] = now
+
+	return true
+}
+
+// place sends an order placement, and reconciles ambiguous failures instead of retrying them.
+func (op *orders) place(
+	ctx context.Context, endpoint APIEndpoint, req any, match orderMatch,
+) (*models.Order, error) {
+	if op.enforcePreflight {
+		violations, err := op.preflight(ctx, match)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(violations) > 0 {
+			return nil, &PreflightError{Violations: violations}
+		}
+	}
+
+	sentAt := time.Now()
+
+	order, err := runOperation[models.Order](ctx, op.api, http.MethodPost, endpoint, req).Object()
+	if err == nil {
+		op.claims.claim(order)
+	}
+
+	if err == nil || !errors.Is(err, errOutcomeUnknown) {
+		return order, err
+	}
+
+	// the deadline of ctx may be what made the placement ambiguous
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), reconcileTimeout)
+	defer cancel()
+
+	return op.reconcile(ctx, match, sentAt, err)
+}
+
+// reconcile searches the pending orders, then the historical orders, for the order
+// whose placement failed ambiguously. The orders already claimed by another placement are skipped.
+func (op *orders) reconcile(
+	ctx context.Context, match orderMatch, sentAt time.Time, placeErr error,
+) (*models.Order, error) {
+	failedAt := time.Now()
+	outcomeUnknown := &OrderOutcomeUnknownError{
+		Ticker:       match.ticker,
+		Quantity:     match.quantity,
+		SentAt:       sentAt,
+		Err:          placeErr,
+		ReconcileErr: nil,
+	}
+
+	pending := runOperation[models.Order](ctx, op.api, http.MethodGet, GetAllPendingOrders, nil)
+
+	for order, err := range pending.All() {
+		if err != nil {
+			outcomeUnknown.ReconcileErr = err
+
+			return nil, outcomeUnknown
+		}
+
+		if match.matches(order, sentAt, failedAt) && op.claims.claim(order) {
+			return order, nil
+		}
+	}
+
+	query := models.HistoryQuery{Cursor: 0, Ticker: match.ticker, Limit: 0}
+	endpoint := GetHistoricalOrders.withQuery(query.Values())
+
+	for fill, err := range newPaginator[models.OrderFill](ctx, op.api, http.MethodGet, endpoint).All() {
+		if err != nil {
+			outcomeUnknown.ReconcileErr = err
+
+			return nil, outcomeUnknown
+		}
+
+		if match.matches(&fill.Order, sentAt, failedAt) && op.claims.claim(&fill.Order) {
+			return &fill.Order, nil
+		}
+
+		// history is sorted from the most recent
+		if fill.CreatedAt.Before(sentAt.Add(-reconcileClockSkew)) {
+			break
+		}
+	}
+
+	return nil, outcomeUnknown
+}
@@ -0,0 +1,227 @@
+package trading212
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+func newReconcileMockAPI(t *testing.T, placeStatus int, pending string, history string) (*API, func() int) {
+	t.Helper()
+
+	var placed atomic.Int32
+
+	mockAPI := newRoutesMockAPI(t, map[APIEndpoint]http.HandlerFunc{
+		http.MethodPost + " " + PlaceLimitOrder: func(w http.ResponseWriter, _ *http.Request) {
+			placed.Add(1)
+			w.WriteHeader(placeStatus)
+		},
+		http.MethodGet + " " + GetAllPendingOrders: serveBody(pending),
+		http.MethodGet + " " + GetHistoricalOrders: serveBody(history),
+	})
+
+	fastPolicy := NewExponentialBackoff()
+	fastPolicy.BaseDelay = time.Millisecond
+	_ = WithRetryPolicy(fastPolicy)(mockAPI)
+
+	return mockAPI, func() int {
+		return int(placed.Load())
+	}
+}
+
+func Test_Orders_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Now().UTC().Format(time.RFC3339)
+	matching := fmt.Sprintf(
+		`{"id": 42, "ticker": "AAPL_US_EQ", "type": "LIMIT", "side": "BUY", "quantity": 2, "limitPrice": 150, `+
+			`"createdAt": %q}`, createdAt,
+	)
+	other := fmt.Sprintf(
+		`{"id": 43, "ticker": "MSFT_US_EQ", "type": "LIMIT", "side": "BUY", "quantity": 2, "limitPrice": 150, `+
+			`"createdAt": %q}`, createdAt,
+	)
+
+	stale := strings.Replace(matching, createdAt, time.Now().Add(-time.Minute).UTC().Format(time.RFC3339), 1)
+	request := newReconcileRequest()
+
+	tests := []struct {
+		name        string
+		placeStatus int
+		pending     string
+		history     string
+		wantID      uint
+		wantErr     error
+	}{
+		{
+			name:        "PlaceLimitOrder should find the order in pending orders after a timeout",
+			placeStatus: http.StatusRequestTimeout,
+			pending:     "[" + other + "," + matching + "]",
+			history:     `{"items": [], "nextPagePath": null}`,
+			wantID:      42,
+		},
+		{
+			name:        "PlaceLimitOrder should find the order in historical orders after a server error",
+			placeStatus: http.StatusBadGateway,
+			pending:     "[]",
+			history:     `{"items": [{"order": ` + matching + `, "fill": {}}], "nextPagePath": null}`,
+			wantID:      42,
+		},
+		{
+			name:        "PlaceLimitOrder should report an unknown outcome when no order matches",
+			placeStatus: http.StatusServiceUnavailable,
+			pending:     "[" + other + "]",
+			history:     `{"items": [], "nextPagePath": null}`,
+			wantErr:     errOutcomeUnknown,
+		},
+		{
+			name:        "PlaceLimitOrder should report an unknown outcome when the pending orders are broken",
+			placeStatus: http.StatusBadGateway,
+			pending:     "[" + other + ", {",
+			history:     `{"items": [{"order": ` + matching + `, "fill": {}}], "nextPagePath": null}`,
+			wantErr:     errOutcomeUnknown,
+		},
+		{
+			name:        "PlaceLimitOrder should not match an identical order created before the placement",
+			placeStatus: http.StatusGatewayTimeout,
+			pending:     "[" + stale + "]",
+			history:     `{"items": [], "nextPagePath": null}`,
+			wantErr:     errOutcomeUnknown,
+		},
+		{
+			name:        "PlaceLimitOrder should not reconcile definite failures",
+			placeStatus: http.StatusBadRequest,
+			pending:     "[" + matching + "]",
+			history:     `{"items": [], "nextPagePath": null}`,
+			wantErr:     errNon200,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				mockAPI, placed := newReconcileMockAPI(t, tt.placeStatus, tt.pending, tt.history)
+
+				order, err := mockAPI.Orders.PlaceLimitOrderWithContext(context.Background(), request)
+
+				if got := placed(); got != 1 {
+					t.Errorf("PlaceLimitOrder() should be sent once, sent %d times", got)
+				}
+
+				if tt.wantErr != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("PlaceLimitOrder() error = %v, want %v", err, tt.wantErr)
+					}
+
+					var outcomeUnknown *OrderOutcomeUnknownError
+					if errors.Is(err, errOutcomeUnknown) && !errors.As(err, &outcomeUnknown) {
+						t.Errorf("PlaceLimitOrder() error should be a *OrderOutcomeUnknownError, got %T", err)
+					}
+
+					return
+				}
+
+				if err != nil {
+					t.Fatalf("PlaceLimitOrder() unexpected error = %v", err)
+				}
+
+				if order.ID != tt.wantID {
+					t.Errorf("PlaceLimitOrder() order id = %d, want %d", order.ID, tt.wantID)
+				}
+			},
+		)
+	}
+}
+
+func newReconcileRequest() models.LimitOrderRequest {
+	request := models.LimitOrderRequest{}
+	request.Ticker = "AAPL_US_EQ"
+	request.Quantity = models.DecimalFromInt(2)
+	request.LimitPrice = models.DecimalFromInt(150)
+
+	return request
+}
+
+func Test_Orders_Reconcile_Claimed(t *testing.T) {
+	t.Parallel()
+
+	matching := fmt.Sprintf(
+		`[{"id": 42, "ticker": "AAPL_US_EQ", "type": "LIMIT", "side": "BUY", "quantity": 2, "limitPrice": 150, `+
+			`"createdAt": %q}]`, time.Now().UTC().Format(time.RFC3339),
+	)
+
+	mockAPI, _ := newReconcileMockAPI(t, http.StatusBadGateway, matching, `{"items": [], "nextPagePath": null}`)
+
+	order, err := mockAPI.Orders.PlaceLimitOrderWithContext(context.Background(), newReconcileRequest())
+	if err != nil || order.ID != 42 {
+		t.Fatalf("PlaceLimitOrder() = %v, %v, want order 42", order, err)
+	}
+
+	// the same order cannot be the outcome of another placement
+	_, err = mockAPI.Orders.PlaceLimitOrderWithContext(context.Background(), newReconcileRequest())
+	if !errors.Is(err, errOutcomeUnknown) {
+		t.Errorf("PlaceLimitOrder() error = %v, want %v", err, errOutcomeUnknown)
+	}
+}
+
+func Test_Orders_Reconcile_Deadline(t *testing.T) {
+	t.Parallel()
+
+	mockAPI := newRoutesMockAPI(t, map[APIEndpoint]http.HandlerFunc{
+		http.MethodPost + " " + PlaceLimitOrder: func(http.ResponseWriter, *http.Request) {
+			// outlive the deadline of the placement
+			time.Sleep(100 * time.Millisecond)
+		},
+		http.MethodGet + " " + GetAllPendingOrders: func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = fmt.Fprintf(w,
+				`[{"id": 42, "ticker": "AAPL_US_EQ", "type": "LIMIT", "side": "BUY", "quantity": 2, `+
+					`"limitPrice": 150, "createdAt": %q}]`, time.Now().UTC().Format(time.RFC3339))
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	order, err := mockAPI.Orders.PlaceLimitOrderWithContext(ctx, newReconcileRequest())
+	if err != nil || order.ID != 42 {
+		t.Errorf("PlaceLimitOrder() = %v, %v, want order 42 found after the deadline", order, err)
+	}
+}
+
+func Test_Orders_Place_RateLimited(t *testing.T) {
+	t.Parallel()
+
+	mockAPI, placed := newReconcileMockAPI(t, http.StatusTooManyRequests, "[]", "")
+
+	_ = WithMaxRetries(3)(mockAPI)
+
+	_, err := mockAPI.Orders.PlaceLimitOrderWithContext(context.Background(), models.LimitOrderRequest{})
+	if !errors.Is(err, errHTTP429) || errors.Is(err, errOutcomeUnknown) {
+		t.Errorf("PlaceLimitOrder() error = %v, want %v", err, errHTTP429)
+	}
+
+	if got := placed(); got != 4 {
+		t.Errorf("PlaceLimitOrder() rate-limited placements should be retried, sent %d times", got)
+	}
+}
+
+func Test_isIdempotent(t *testing.T) {
+	t.Parallel()
+
+	if isIdempotent(http.MethodPost, PlaceMarketOrder) {
+		t.Errorf("isIdempotent() order placement should not be idempotent")
+	}
+
+	if !isIdempotent(http.MethodPost, RequestReport) || !isIdempotent(http.MethodGet, GetAllPendingOrders) {
+		t.Errorf("isIdempotent() other requests should be idempotent")
+	}
+}
@@ -1,9 +1,14 @@
 package trading212
 
 import (
-	trading213 "github.com/cyrbil/go-trading212/pkg/trading212/models"
+	"encoding/json"
+	"errors"
 	"iter"
+	"math"
+	"slices"
 	"testing"
+
+	trading213 "github.com/cyrbil/go-trading212/pkg/trading212/models"
 )
 
 func Test_Orders_Operations(t *testing.T) {
@@ -249,3 +254,164 @@ func Test_Orders_Operations(t *testing.T) {
 		},
 	)
 }
+
+func Test_Orders_Builders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		build      func() (any, error)
+		wantFields []string
+	}{
+		{
+			name: "limit buy",
+			build: func() (any, error) {
+				return trading213.NewLimitOrder("AAPL_US_EQ").Buy(10).At(150).GTC().Build()
+			},
+			wantFields: nil,
+		},
+		{
+			name: "limit without price",
+			build: func() (any, error) {
+				return trading213.NewLimitOrder("AAPL_US_EQ").Sell(10).Day().Build()
+			},
+			wantFields: []string{"limitPrice"},
+		},
+		{
+			name: "limit with negative quantity and bad ticker",
+			build: func() (any, error) {
+				return trading213.NewLimitOrder("AAPL US").Buy(-10).At(150).Build()
+			},
+			wantFields: []string{"quantity", "ticker"},
+		},
+		{
+			name: "market sell",
+			build: func() (any, error) {
+				return trading213.NewMarketOrder("AAPL_US_EQ").Sell(1.5).ExtendedHours().Build()
+			},
+			wantFields: nil,
+		},
+		{
+			name: "market without side",
+			build: func() (any, error) {
+				return trading213.NewMarketOrder("AAPL_US_EQ").Build()
+			},
+			wantFields: []string{"quantity"},
+		},
+		{
+			name: "stop with infinite price",
+			build: func() (any, error) {
+				return trading213.NewStopOrder("AAPL_US_EQ").Sell(1).Stop(math.Inf(1)).Build()
+			},
+			// not a decimal, so no stop price either
+			wantFields: []string{"stopPrice", "stopPrice"},
+		},
+		{
+			name: "stop limit",
+			build: func() (any, error) {
+				return trading213.NewStopLimitOrder("AAPL_US_EQ").Buy(1).Stop(150).At(152).Day().Build()
+			},
+			wantFields: nil,
+		},
+		{
+			name: "stop limit without prices",
+			build: func() (any, error) {
+				return trading213.NewStopLimitOrder("AAPL_US_EQ").Buy(1).Build()
+			},
+			wantFields: []string{"limitPrice", "stopPrice"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tt.build()
+			if got := requestErrorFields(err); !slices.Equal(got, tt.wantFields) {
+				t.Errorf("Build() error = %v, want invalid fields %v", err, tt.wantFields)
+			}
+		})
+	}
+}
+
+func Test_Orders_Builders_Request(t *testing.T) {
+	t.Parallel()
+
+	req, err := trading213.NewLimitOrder("AAPL_US_EQ").Sell(0.1).At(150.05).GTC().Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if req.Ticker != "AAPL_US_EQ" || req.TimeInForce != trading213.TimeInForceGoodTillCancel {
+		t.Errorf("Build() = %+v", req)
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	want := `{"ticker":"AAPL_US_EQ","quantity":-0.1,"limitPrice":150.05,"timeInForce":"GOOD_TILL_CANCEL"}`
+	if string(body) != want {
+		t.Errorf("json.Marshal() = %s, want %s", body, want)
+	}
+}
+
+// requestErrorFields returns the sorted fields of the *models.RequestError joined in err.
+func requestErrorFields(err error) []string {
+	var fields []string
+
+	var walk func(err error)
+	walk = func(err error) {
+		var requestErr *trading213.RequestError
+		if joined, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint // walking joined errors.
+			for _, err := range joined.Unwrap() {
+				walk(err)
+			}
+		} else if errors.As(err, &requestErr) {
+			fields = append(fields, requestErr.Field)
+		}
+	}
+	walk(err)
+	slices.Sort(fields)
+
+	return fields
+}
+
+func Test_OrderStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status       trading213.OrderStatus
+		wantKnown    bool
+		wantTerminal bool
+		wantActive   bool
+	}{
+		{status: trading213.OrderStatusNew, wantKnown: true, wantTerminal: false, wantActive: true},
+		{status: trading213.OrderStatusPartiallyFilled, wantKnown: true, wantTerminal: false, wantActive: true},
+		{status: trading213.OrderStatusReplacing, wantKnown: true, wantTerminal: false, wantActive: true},
+		{status: trading213.OrderStatusFilled, wantKnown: true, wantTerminal: true, wantActive: false},
+		{status: trading213.OrderStatusCancelled, wantKnown: true, wantTerminal: true, wantActive: false},
+		{status: trading213.OrderStatusRejected, wantKnown: true, wantTerminal: true, wantActive: false},
+		{status: trading213.OrderStatusReplaced, wantKnown: true, wantTerminal: true, wantActive: false},
+		{status: "EXPIRED", wantKnown: false, wantTerminal: false, wantActive: false},
+		{status: "", wantKnown: false, wantTerminal: false, wantActive: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.status.IsKnown(); got != tt.wantKnown {
+				t.Errorf("IsKnown() = %v, want %v", got, tt.wantKnown)
+			}
+
+			if got := tt.status.IsTerminal(); got != tt.wantTerminal {
+				t.Errorf("IsTerminal() = %v, want %v", got, tt.wantTerminal)
+			}
+
+			if got := tt.status.IsActive(); got != tt.wantActive {
+				t.Errorf("IsActive() = %v, want %v", got, tt.wantActive)
+			}
+		})
+	}
+}
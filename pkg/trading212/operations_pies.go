@@ -1,25 +1,36 @@
 package trading212
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"iter"
+	"maps"
 	"net/http"
+	"slices"
 
 	"github.com/cyrbil/go-trading212/pkg/trading212/models"
 )
 
+var errInvalidRequest = errors.New("invalid request")
+
 type operationFetchAllPies interface {
 	// FetchAllPies operation.
 	// Fetches all pies for the account.
 	// See: https://docs.trading212.com/api/pies-(deprecated)/getall
 	FetchAllPies() (iter.Seq[*models.PieSummary], error)
+	// FetchAllPiesWithContext is FetchAllPies bound to ctx.
+	FetchAllPiesWithContext(ctx context.Context) (iter.Seq[*models.PieSummary], error)
 }
 
 type operationCreatePie interface {
 	// CreatePie operation.
 	// Creates a pie for the account by given params.
+	// With WithPieValidation, the request is checked before being sent, its tickers should be available instruments.
 	// See: https://docs.trading212.com/api/pies-(deprecated)/create
 	CreatePie(req models.PieRequest) (*models.PieDetails, error)
+	// CreatePieWithContext is CreatePie bound to ctx.
+	CreatePieWithContext(ctx context.Context, req models.PieRequest) (*models.PieDetails, error)
 }
 
 type operationDeletePie interface {
@@ -27,6 +38,8 @@ type operationDeletePie interface {
 	// Deletes a pie by given id.
 	// See: https://docs.trading212.com/api/pies-(deprecated)/delete
 	DeletePie(id uint) error
+	// DeletePieWithContext is DeletePie bound to ctx.
+	DeletePieWithContext(ctx context.Context, id uint) error
 }
 
 type operationFetchPie interface {
@@ -34,13 +47,18 @@ type operationFetchPie interface {
 	// Fetches a pies for the account with detailed information.
 	// See: https://docs.trading212.com/api/pies-(deprecated)/getdetailed
 	FetchPie(id uint) (*models.PieDetails, error)
+	// FetchPieWithContext is FetchPie bound to ctx.
+	FetchPieWithContext(ctx context.Context, id uint) (*models.PieDetails, error)
 }
 
 type operationUpdatePie interface {
 	// UpdatePie operation.
-	// Updates a pie for the account by given params.
+	// Updates a pie for the account by given params, see models.NewPieRequestFrom to edit a pie.
+	// With WithPieValidation, the request is checked before being sent, its tickers should be available instruments.
 	// See: https://docs.trading212.com/api/pies-(deprecated)/update
 	UpdatePie(id uint, req models.PieRequest) (*models.PieDetails, error)
+	// UpdatePieWithContext is UpdatePie bound to ctx.
+	UpdatePieWithContext(ctx context.Context, id uint, req models.PieRequest) (*models.PieDetails, error)
 }
 
 type operationDuplicatePies interface {
@@ -48,6 +66,8 @@ type operationDuplicatePies interface {
 	// Duplicates a pie for the account.
 	// See: https://docs.trading212.com/api/pies-(deprecated)/duplicatepie
 	DuplicatePies(id uint, req models.PieMetaRequest) (*models.PieDetails, error)
+	// DuplicatePiesWithContext is DuplicatePies bound to ctx.
+	DuplicatePiesWithContext(ctx context.Context, id uint, req models.PieMetaRequest) (*models.PieDetails, error)
 }
 
 type piesOperations interface {
@@ -61,36 +81,109 @@ type piesOperations interface {
 
 type pies struct {
 	api requestMaker
+	// instruments to check the pie tickers against, cached when configured.
+	instruments instrumentsOperations
+	// validate the requests before sending them, see WithPieValidation.
+	validate bool
 }
 
 func (op *pies) FetchAllPies() (iter.Seq[*models.PieSummary], error) {
-	return runOperation[models.PieSummary](op.api, http.MethodGet, GetAllPies, nil).Items()
+	return op.FetchAllPiesWithContext(context.Background())
+}
+
+func (op *pies) FetchAllPiesWithContext(ctx context.Context) (iter.Seq[*models.PieSummary], error) {
+	return runOperation[models.PieSummary](ctx, op.api, http.MethodGet, GetAllPies, nil).Items()
 }
 
 func (op *pies) CreatePie(req models.PieRequest) (*models.PieDetails, error) {
-	return runOperation[models.PieDetails](op.api, http.MethodPost, CreatePie, req).Object()
+	return op.CreatePieWithContext(context.Background(), req)
+}
+
+func (op *pies) CreatePieWithContext(ctx context.Context, req models.PieRequest) (*models.PieDetails, error) {
+	if err := op.check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return runOperation[models.PieDetails](ctx, op.api, http.MethodPost, CreatePie, req).Object()
 }
 
 func (op *pies) DeletePie(id uint) error {
+	return op.DeletePieWithContext(context.Background(), id)
+}
+
+func (op *pies) DeletePieWithContext(ctx context.Context, id uint) error {
 	endpoint := APIEndpoint(fmt.Sprintf("%s/%d", DeletePie, id))
 
-	return runOperation[models.Empty](op.api, http.MethodDelete, endpoint, nil).err
+	return runOperation[models.Empty](ctx, op.api, http.MethodDelete, endpoint, nil).discard()
 }
 
 func (op *pies) FetchPie(id uint) (*models.PieDetails, error) {
+	return op.FetchPieWithContext(context.Background(), id)
+}
+
+func (op *pies) FetchPieWithContext(ctx context.Context, id uint) (*models.PieDetails, error) {
 	endpoint := APIEndpoint(fmt.Sprintf("%s/%d", FetchPie, id))
 
-	return runOperation[models.PieDetails](op.api, http.MethodGet, endpoint, nil).Object()
+	return runOperation[models.PieDetails](ctx, op.api, http.MethodGet, endpoint, nil).Object()
 }
 
 func (op *pies) UpdatePie(id uint, req models.PieRequest) (*models.PieDetails, error) {
+	return op.UpdatePieWithContext(context.Background(), id, req)
+}
+
+func (op *pies) UpdatePieWithContext(ctx context.Context, id uint, req models.PieRequest) (*models.PieDetails, error) {
+	if err := op.check(ctx, req); err != nil {
+		return nil, err
+	}
+
 	endpoint := APIEndpoint(fmt.Sprintf("%s/%d", UpdatePie, id))
 
-	return runOperation[models.PieDetails](op.api, http.MethodPost, endpoint, req).Object()
+	return runOperation[models.PieDetails](ctx, op.api, http.MethodPost, endpoint, req).Object()
 }
 
 func (op *pies) DuplicatePies(id uint, req models.PieMetaRequest) (*models.PieDetails, error) {
+	return op.DuplicatePiesWithContext(context.Background(), id, req)
+}
+
+func (op *pies) DuplicatePiesWithContext(
+	ctx context.Context, id uint, req models.PieMetaRequest,
+) (*models.PieDetails, error) {
 	endpoint := APIEndpoint(fmt.Sprintf("%s/%d/duplicate", DuplicatePie, id))
 
-	return runOperation[models.PieDetails](op.api, http.MethodPost, endpoint, req).Object()
+	return runOperation[models.PieDetails](ctx, op.api, http.MethodPost, endpoint, req).Object()
+}
+
+// check validates the request, then checks its tickers are available instruments, when enabled.
+func (op *pies) check(ctx context.Context, req models.PieRequest) error {
+	if !op.validate {
+		return nil
+	}
+
+	if err := req.Validate(); err != nil {
+		return errors.Join(errInvalidRequest, err)
+	}
+
+	unknown := maps.Clone(req.InstrumentShares)
+	for instrument, err := range op.instruments.availableInstruments(ctx) {
+		if err != nil {
+			return err
+		}
+
+		delete(unknown, string(instrument.Ticker))
+	}
+
+	errs := []error{}
+	for _, ticker := range slices.Sorted(maps.Keys(unknown)) {
+		errs = append(errs, &models.RequestError{
+			Request: "PieRequest",
+			Field:   "instrumentShares." + ticker,
+			Err:     fmt.Errorf("%w: %q", errUnknownTicker, ticker),
+		})
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(append([]error{errInvalidRequest}, errs...)...)
+	}
+
+	return nil
 }
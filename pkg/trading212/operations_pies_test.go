@@ -1,9 +1,18 @@
 package trading212
 
 import (
-	trading213 "github.com/cyrbil/go-trading212/pkg/trading212/models"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"iter"
+	"net/http"
+	"reflect"
+	"slices"
+	"sync"
 	"testing"
+	"time"
+
+	trading213 "github.com/cyrbil/go-trading212/pkg/trading212/models"
 )
 
 func Test_Pies_Operations(t *testing.T) {
@@ -236,3 +245,238 @@ func Test_Pies_Operations(t *testing.T) {
 		},
 	)
 }
+
+func Test_Pies_Builder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		builder    *trading213.PieRequestBuilder
+		wantFields []string
+	}{
+		{
+			name: "complete",
+			builder: trading213.NewPieRequest("Tech").Icon("Technology").Goal(10000).
+				EndDate(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)).Reinvest().
+				Share("AAPL_US_EQ", 0.5).Share("MSFT_US_EQ", 0.5),
+			wantFields: nil,
+		},
+		{
+			name:       "paying out",
+			builder:    trading213.NewPieRequest("Income").PayOutDividends().Share("VOD_LSE_EQ", 1),
+			wantFields: nil,
+		},
+		{
+			name:       "unnamed with negative goal",
+			builder:    trading213.NewPieRequest("").Goal(-1).Share("AAPL_US_EQ", 1),
+			wantFields: []string{"goal", "name"},
+		},
+		{
+			name:       "no shares",
+			builder:    trading213.NewPieRequest("Empty"),
+			wantFields: []string{"instrumentShares"},
+		},
+		{
+			name:       "shares not summing to 1",
+			builder:    trading213.NewPieRequest("Tech").Share("AAPL_US_EQ", 0.5).Share("MSFT_US_EQ", 0.4),
+			wantFields: []string{"instrumentShares"},
+		},
+		{
+			name:       "shares within tolerance",
+			builder:    trading213.NewPieRequest("Tech").Share("A", 1.0/3).Share("B", 1.0/3).Share("C", 0.3333),
+			wantFields: nil,
+		},
+		{
+			name:       "normalized shares",
+			builder:    trading213.NewPieRequest("Tech").Share("AAPL_US_EQ", 2).Share("MSFT_US_EQ", 3).Normalize(),
+			wantFields: nil,
+		},
+		{
+			name:       "normalizing nothing",
+			builder:    trading213.NewPieRequest("Tech").Normalize(),
+			wantFields: []string{"instrumentShares", "instrumentShares"},
+		},
+		{
+			name: "negative and duplicate shares",
+			builder: trading213.NewPieRequest("Tech").Share("AAPL_US_EQ", 1).Share("aapl_us_eq", -0.5).
+				Share("MSFT_US_EQ", 0.2).Share("MSFT_US_EQ", 0.5),
+			wantFields: []string{
+				"instrumentShares.MSFT_US_EQ", "instrumentShares.aapl_us_eq", "instrumentShares.aapl_us_eq",
+			},
+		},
+		{
+			name:       "invalid ticker",
+			builder:    trading213.NewPieRequest("Tech").Share("AAPL US", 1),
+			wantFields: []string{"instrumentShares.AAPL US"},
+		},
+		{
+			name:       "from no details",
+			builder:    trading213.NewPieRequestFrom(nil),
+			wantFields: []string{"details", "instrumentShares", "name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tt.builder.Build()
+			if got := requestErrorFields(err); !slices.Equal(got, tt.wantFields) {
+				t.Errorf("Build() error = %v, want invalid fields %v", err, tt.wantFields)
+			}
+		})
+	}
+}
+
+const piesMockDetails = `{
+	"instruments": [
+		{"ticker": "AAPL_US_EQ", "expectedShare": 0.6, "currentShare": 0.55},
+		{"ticker": "MSFT_US_EQ", "expectedShare": 0.4, "currentShare": 0.45}
+	],
+	"settings": {
+		"id": 7, "name": "Tech", "icon": "Technology", "goal": 1000, "dividendCashAction": "REINVEST",
+		"instrumentShares": {"AAPL_US_EQ": 0.6, "MSFT_US_EQ": 0.4}
+	}
+}`
+
+func newPiesMockAPI(t *testing.T) (*API, func() []map[string]any) {
+	t.Helper()
+
+	var (
+		mutex  sync.Mutex
+		bodies []map[string]any
+	)
+
+	write := func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		mutex.Lock()
+		bodies = append(bodies, body)
+		mutex.Unlock()
+
+		_, _ = fmt.Fprint(w, piesMockDetails)
+	}
+
+	mockAPI := newRoutesMockAPI(t, map[APIEndpoint]http.HandlerFunc{
+		http.MethodGet + " " + GetAllAvailableInstruments: serveBody(
+			`[{"ticker": "AAPL_US_EQ"}, {"ticker": "MSFT_US_EQ"}, {"ticker": "TSLA_US_EQ"}]`,
+		),
+		http.MethodGet + " " + FetchPie + "/{id}":   serveBody(piesMockDetails),
+		http.MethodPost + " " + CreatePie:           write,
+		http.MethodPost + " " + UpdatePie + "/{id}": write,
+	})
+
+	mockAPI.Pies.(*pies).validate = true //nolint:forcetypeassert // set by NewAPI
+
+	return mockAPI, func() []map[string]any {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return slices.Clone(bodies)
+	}
+}
+
+func Test_Pies_Write(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		operation func(api *API) error
+		wantErr   error
+		wantSent  map[string]any
+	}{
+		{
+			name: "CreatePie should send the instrument shares",
+			operation: func(api *API) error {
+				req, err := trading213.NewPieRequest("Tech").Share("AAPL_US_EQ", 1).Share("TSLA_US_EQ", 1).
+					Normalize().Build()
+				if err != nil {
+					return err
+				}
+
+				_, err = api.Pies.CreatePie(req)
+
+				return err
+			},
+			wantErr:  nil,
+			wantSent: map[string]any{"AAPL_US_EQ": 0.5, "TSLA_US_EQ": 0.5},
+		},
+		{
+			name: "UpdatePie should edit a fetched pie",
+			operation: func(api *API) error {
+				details, err := api.Pies.FetchPie(7)
+				if err != nil {
+					return err
+				}
+
+				req, err := trading213.NewPieRequestFrom(details).Unshare("MSFT_US_EQ").Share("TSLA_US_EQ", 0.4).Build()
+				if err != nil {
+					return err
+				}
+
+				_, err = api.Pies.UpdatePie(details.Settings.ID, req)
+
+				return err
+			},
+			wantErr:  nil,
+			wantSent: map[string]any{"AAPL_US_EQ": 0.6, "TSLA_US_EQ": 0.4},
+		},
+		{
+			name: "CreatePie should reject invalid shares",
+			operation: func(api *API) error {
+				req := trading213.PieRequest{}
+				req.Name = "Tech"
+				req.InstrumentShares = map[string]float64{"AAPL_US_EQ": 0.5}
+
+				_, err := api.Pies.CreatePie(req)
+
+				return err
+			},
+			wantErr:  errInvalidRequest,
+			wantSent: nil,
+		},
+		{
+			name: "UpdatePie should reject unknown tickers",
+			operation: func(api *API) error {
+				req, err := trading213.NewPieRequest("Tech").Share("NOPE_US_EQ", 1).Build()
+				if err != nil {
+					return err
+				}
+
+				_, err = api.Pies.UpdatePie(7, req)
+
+				return err
+			},
+			wantErr:  errUnknownTicker,
+			wantSent: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockAPI, sent := newPiesMockAPI(t)
+
+			err := tt.operation(mockAPI)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("operation error = %v, want %v", err, tt.wantErr)
+			}
+
+			bodies := sent()
+			if tt.wantSent == nil {
+				if len(bodies) != 0 {
+					t.Errorf("operation sent %v, want nothing", bodies)
+				}
+
+				return
+			}
+
+			if len(bodies) != 1 || !reflect.DeepEqual(bodies[0]["instrumentShares"], tt.wantSent) {
+				t.Errorf("operation sent %v, want shares %v", bodies, tt.wantSent)
+			}
+		})
+	}
+}
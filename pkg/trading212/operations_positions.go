@@ -1,6 +1,7 @@
 package trading212
 
 import (
+	"context"
 	"iter"
 	"net/http"
 
@@ -12,6 +13,8 @@ type positionsOperations interface {
 	// Fetch all open positions for your account.
 	// See: https://docs.trading212.com/api/positions/getpositions
 	GetAllPositions() (iter.Seq[*models.Position], error)
+	// GetAllPositionsWithContext is GetAllPositions bound to ctx.
+	GetAllPositionsWithContext(ctx context.Context) (iter.Seq[*models.Position], error)
 }
 
 type positions struct {
@@ -19,5 +22,9 @@ type positions struct {
 }
 
 func (op *positions) GetAllPositions() (iter.Seq[*models.Position], error) {
-	return runOperation[models.Position](op.api, http.MethodGet, GetAllPositions, nil).Items()
+	return op.GetAllPositionsWithContext(context.Background())
+}
+
+func (op *positions) GetAllPositionsWithContext(ctx context.Context) (iter.Seq[*models.Position], error) {
+	return runOperation[models.Position](ctx, op.api, http.MethodGet, GetAllPositions, nil).Items()
 }
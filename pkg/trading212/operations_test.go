@@ -1,6 +1,8 @@
 package trading212
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,8 +10,8 @@ import (
 	"iter"
 	"net/http"
 	"net/http/httptest"
-	"net/url"
 	"testing"
+	"time"
 )
 
 type mockRequestMaker struct {
@@ -18,10 +20,16 @@ type mockRequestMaker struct {
 }
 
 //nolint:ireturn
-func (mock *mockRequestMaker) NewRequest(_ string, _ APIEndpoint, _ io.Reader) (IRequest, error) {
+func (mock *mockRequestMaker) NewRequestWithContext(
+	_ context.Context, _ string, _ APIEndpoint, _ io.Reader,
+) (IRequest, error) {
 	return mock.request, mock.err
 }
 
+func (mock *mockRequestMaker) responseDecoding() decoding {
+	return decoding{mode: DecodingStrict, drifts: nil}
+}
+
 type mockIRequest struct {
 	data        *json.RawMessage
 	err         error
@@ -32,6 +40,14 @@ func (mock *mockIRequest) Do() (*json.RawMessage, error) {
 	return mock.data, mock.err
 }
 
+func (mock *mockIRequest) stream() (io.ReadCloser, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+
+	return io.NopCloser(bytes.NewReader(*mock.data)), nil
+}
+
 func (mock *mockIRequest) http() *http.Request {
 	return mock.httpRequest
 }
@@ -44,7 +60,7 @@ func Test_runOperation(t *testing.T) {
 			t.Parallel()
 
 			api := &mockRequestMaker{err: errors.New("mocked error")}
-			response := runOperation[any](api, "", "", nil)
+			response := runOperation[any](context.Background(), api, "", "", nil)
 
 			if response.err == nil {
 				t.Error("expected error, got nil")
@@ -57,7 +73,7 @@ func Test_runOperation(t *testing.T) {
 			t.Parallel()
 
 			api := &mockRequestMaker{request: &mockIRequest{err: errors.New("mocked error")}}
-			response := runOperation[any](api, "", "", nil)
+			response := runOperation[any](context.Background(), api, "", "", nil)
 
 			if response.err == nil {
 				t.Error("expected error, got nil")
@@ -69,21 +85,51 @@ func Test_runOperation(t *testing.T) {
 func newMockAPI(handler http.Handler) (*API, func(), error) {
 	ts := httptest.NewTLSServer(handler)
 
-	mockAPI, err := NewAPIDemo("foo", "bar")
+	mockAPI, err := NewAPIDemo(
+		"foo", "bar",
+		WithHTTPClient(ts.Client()),
+		WithTimeout(defaultTimeout),
+		WithBaseURL(APIURL(ts.URL)),
+		WithDecodingMode(DecodingStrict),
+	)
 	if err != nil {
 		return nil, ts.Close, errors.Join(errors.New("error creating mock api"), err)
 	}
 
-	ts.Client().Timeout = mockAPI.client.Timeout
-	mockAPI.client = ts.Client()
-	mockAPI.domain, err = url.Parse(ts.URL)
-	if err != nil {
-		return nil, ts.Close, errors.Join(errors.New("error parsing mock url"), err)
+	// do not wait for the documented rate-limits against the mock server
+	mockAPI.rateLimits.sleep = func(ctx context.Context, _ time.Duration) error {
+		return context.Cause(ctx)
 	}
 
 	return mockAPI, ts.Close, nil
 }
 
+// newRoutesMockAPI serves the routes, keyed by their "METHOD path" pattern, other requests are answered 404.
+func newRoutesMockAPI(t *testing.T, routes map[APIEndpoint]http.HandlerFunc) *API {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	for pattern, handler := range routes {
+		mux.HandleFunc(string(pattern), handler)
+	}
+
+	mockAPI, terminate, err := newMockAPI(mux)
+	t.Cleanup(terminate)
+
+	if err != nil {
+		t.Fatalf("Error creating mock api; %v", err)
+	}
+
+	return mockAPI
+}
+
+// serveBody answers with the body.
+func serveBody(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, body)
+	}
+}
+
 //nolint:ireturn
 func validateOperation[T any](t *testing.T, operation func(*API) (T, error), mockData string) T {
 	t.Helper()
@@ -146,3 +192,106 @@ func validateOperationItems[T any](t *testing.T, operation func(*API) (iter.Seq[
 		}
 	}
 }
+
+func Test_runOperation_context(t *testing.T) {
+	t.Parallel()
+
+	t.Run(
+		"runOperation should stop on a cancelled context", func(t *testing.T) {
+			t.Parallel()
+
+			mockAPI, terminate, err := newMockAPI(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+					},
+				),
+			)
+			defer terminate()
+
+			if err != nil {
+				t.Fatalf("Error creating mock api; %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err = mockAPI.Account.GetAccountSummaryWithContext(ctx)
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("expected %v, got %v", context.Canceled, err)
+			}
+		},
+	)
+
+	t.Run(
+		"runOperation should stop retrying once the context is done", func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			mockAPI, terminate, err := newMockAPI(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, _ *http.Request) {
+						calls++
+						w.WriteHeader(http.StatusTooManyRequests)
+					},
+				),
+			)
+			defer terminate()
+
+			if err != nil {
+				t.Fatalf("Error creating mock api; %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			_, err = mockAPI.Positions.GetAllPositionsWithContext(ctx)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+			}
+
+			if calls != 1 {
+				t.Errorf("expected a single call before the deadline, got %d", calls)
+			}
+		},
+	)
+
+	t.Run(
+		"runOperation should keep the context usable for the following pages", func(t *testing.T) {
+			t.Parallel()
+
+			mockAPI, terminate, err := newMockAPI(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Query().Get("cursor") == "" {
+							_, _ = fmt.Fprintln(w, `{"items": [{"reference": "1"}], "nextPagePath": "next"}`)
+
+							return
+						}
+
+						_, _ = fmt.Fprintln(w, `{"items": [{"reference": "2"}], "nextPagePath": null}`)
+					},
+				),
+			)
+			defer terminate()
+
+			if err != nil {
+				t.Fatalf("Error creating mock api; %v", err)
+			}
+
+			items, err := mockAPI.HistoricalEvents.GetTransactionsWithContext(context.Background())
+			if err != nil {
+				t.Fatalf("Error calling operation; %v", err)
+			}
+
+			count := 0
+			for range items {
+				count++
+			}
+
+			if count != 2 {
+				t.Errorf("expected 2 items over 2 pages, got %d", count)
+			}
+		},
+	)
+}
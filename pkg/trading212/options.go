@@ -0,0 +1,244 @@
+package trading212
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 50
+)
+
+var (
+	errInvalidOption = errors.New("invalid option")
+	errNilOption     = errors.New("option value should not be nil")
+	errNegative      = errors.New("option value should not be negative")
+	errPageSize      = fmt.Errorf("page size should be between 1 and %d", maxPageSize)
+	errDecodingMode  = errors.New("unknown decoding mode")
+
+	errInstrumentsCache = errors.New("instruments cache should be created with NewInstrumentsCache")
+)
+
+// Option configures an API client, see NewAPI.
+// Options are applied in the given order, so later options take precedence.
+type Option func(api *API) error
+
+// WithHTTPClient use a copy of the given http.Client to perform the requests.
+// Use it to provide a custom transport, proxy, TLS config or cookie jar.
+func WithHTTPClient(client *http.Client) Option {
+	return func(api *API) error {
+		if client == nil {
+			return fmt.Errorf("%w: http client", errNilOption)
+		}
+
+		clone := *client
+		api.client = &clone
+
+		return nil
+	}
+}
+
+// WithTransport set the http.RoundTripper used by the http client.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(api *API) error {
+		if transport == nil {
+			return fmt.Errorf("%w: transport", errNilOption)
+		}
+
+		api.client.Transport = transport
+
+		return nil
+	}
+}
+
+// WithTimeout set the http client timeout for a single request attempt.
+// A zero timeout means no timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(api *API) error {
+		if timeout < 0 {
+			return fmt.Errorf("%w: timeout %s", errNegative, timeout)
+		}
+
+		api.client.Timeout = timeout
+
+		return nil
+	}
+}
+
+// WithBaseURL override the API url, useful to target a local stand-in.
+func WithBaseURL(apiURL APIURL) Option {
+	return func(api *API) error {
+		if apiURL == "" {
+			return errEmptyDomain
+		}
+
+		domainURL, err := url.Parse(string(apiURL))
+		if err != nil {
+			return errors.Join(errInvalidDomain, err)
+		}
+
+		api.domain = domainURL
+
+		return nil
+	}
+}
+
+// WithLogger set the structured logger used by the client instead of slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(api *API) error {
+		if logger == nil {
+			return fmt.Errorf("%w: logger", errNilOption)
+		}
+
+		api.logger = logger
+
+		return nil
+	}
+}
+
+// WithUserAgent set the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(api *API) error {
+		api.userAgent = userAgent
+
+		return nil
+	}
+}
+
+// WithMaxRetries set how many times a failed request is retried at most, whatever the RetryPolicy says.
+func WithMaxRetries(retries int) Option {
+	return func(api *API) error {
+		if retries < 0 {
+			return fmt.Errorf("%w: max retries %d", errNegative, retries)
+		}
+
+		api.maxRetries = retries
+
+		return nil
+	}
+}
+
+// WithPageSize set the number of items requested per page on paginated endpoints.
+func WithPageSize(size int) Option {
+	return func(api *API) error {
+		if size < 1 || size > maxPageSize {
+			return fmt.Errorf("%w: got %d", errPageSize, size)
+		}
+
+		api.pageSize = size
+
+		return nil
+	}
+}
+
+// WithRateLimiter use the given RateLimiter, so it can be shared between several clients
+// using the same API key.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(api *API) error {
+		if limiter == nil {
+			return fmt.Errorf("%w: rate limiter", errNilOption)
+		}
+
+		api.rateLimits = limiter
+
+		return nil
+	}
+}
+
+// WithRetryPolicy set the RetryPolicy deciding which failed attempts are retried and when.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(api *API) error {
+		if policy == nil {
+			return fmt.Errorf("%w: retry policy", errNilOption)
+		}
+
+		api.retryPolicy = policy
+
+		return nil
+	}
+}
+
+// WithRetryHook set a function called before waiting for each retry,
+// e.g. to collect metrics. Retries are also logged at info level.
+func WithRetryHook(hook RetryHook) Option {
+	return func(api *API) error {
+		api.retryHook = hook
+
+		return nil
+	}
+}
+
+// WithDecodingMode set how the fields of the responses unknown to the models are handled.
+// Defaults to DecodingLenient.
+func WithDecodingMode(mode DecodingMode) Option {
+	return func(api *API) error {
+		if mode != DecodingLenient && mode != DecodingStrict {
+			return fmt.Errorf("%w: got %d", errDecodingMode, mode)
+		}
+
+		api.decodingMode = mode
+
+		return nil
+	}
+}
+
+// WithSchemaDriftHandler set a function called once for each field unknown to the models,
+// e.g. to alert on new fields before they are supported. Only used in DecodingLenient mode.
+// The collected fields are also available with API.SchemaDrifts.
+func WithSchemaDriftHandler(handler SchemaDriftHandler) Option {
+	return func(api *API) error {
+		if handler == nil {
+			return fmt.Errorf("%w: schema drift handler", errNilOption)
+		}
+
+		api.schemaDrifts.handler = handler
+
+		return nil
+	}
+}
+
+// WithInstrumentsCache serve the instruments and exchanges metadata from the cache,
+// refreshing them once expired, see InstrumentsCache.
+func WithInstrumentsCache(cache *InstrumentsCache) Option {
+	return func(api *API) error {
+		if cache == nil || cache.Store == nil {
+			return fmt.Errorf("%w: instruments cache", errNilOption)
+		}
+
+		if cache.now == nil {
+			return errInstrumentsCache
+		}
+
+		api.instrumentsCache = cache
+
+		return nil
+	}
+}
+
+// WithPieValidation refuses to create or update the pies whose request is invalid or holds tickers
+// that are not available instruments. The available instruments are fetched for each request, see
+// WithInstrumentsCache to not hit the rate-limit of their endpoint.
+func WithPieValidation() Option {
+	return func(api *API) error {
+		api.validatePies = true
+
+		return nil
+	}
+}
+
+// WithOrderPreflight refuses to place the orders failing the pre-flight checks, returning a
+// *PreflightError instead, see Preflight. The available instruments are fetched for each placement,
+// see WithInstrumentsCache to not hit the rate-limit of their endpoint, one call per 50 seconds.
+// The buys also fetch the account summary, limited to one call per 5 seconds.
+func WithOrderPreflight() Option {
+	return func(api *API) error {
+		api.enforcePreflight = true
+
+		return nil
+	}
+}
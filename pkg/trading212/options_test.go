@@ -0,0 +1,282 @@
+package trading212
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type mockRoundTripper struct{}
+
+func (mock *mockRoundTripper) RoundTrip(_ *http.Request) (*http.Response, error) {
+	return nil, errors.New("mock error")
+}
+
+func Test_Options(t *testing.T) {
+	t.Parallel()
+
+	client := &http.Client{Timeout: time.Minute}
+	transport := &mockRoundTripper{}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	limiter := NewRateLimiter()
+
+	tests := []struct {
+		name   string
+		opts   []Option
+		err    error
+		verify func(api *API) error
+	}{
+		{
+			name: "WithHTTPClient should use a copy of the client",
+			opts: []Option{WithHTTPClient(client)},
+			verify: func(api *API) error {
+				if api.client == client || api.client.Timeout != time.Minute {
+					return errors.New("client not copied")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "WithHTTPClient should reject nil",
+			opts: []Option{WithHTTPClient(nil)},
+			err:  errNilOption,
+		},
+		{
+			name: "WithTransport should set the client transport",
+			opts: []Option{WithTransport(transport)},
+			verify: func(api *API) error {
+				if api.client.Transport != transport {
+					return errors.New("transport not set")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "WithTransport should reject nil",
+			opts: []Option{WithTransport(nil)},
+			err:  errNilOption,
+		},
+		{
+			name: "WithTimeout should set the client timeout",
+			opts: []Option{WithTimeout(time.Second)},
+			verify: func(api *API) error {
+				if api.client.Timeout != time.Second {
+					return errors.New("timeout not set")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "WithTimeout should reject negative",
+			opts: []Option{WithTimeout(-time.Second)},
+			err:  errNegative,
+		},
+		{
+			name: "WithBaseURL should override the domain",
+			opts: []Option{WithBaseURL("http://127.0.0.1:8080")},
+			verify: func(api *API) error {
+				if api.domain.String() != "http://127.0.0.1:8080" {
+					return errors.New("domain not set")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "WithBaseURL should reject empty",
+			opts: []Option{WithBaseURL("")},
+			err:  errEmptyDomain,
+		},
+		{
+			name: "WithBaseURL should reject invalid",
+			opts: []Option{WithBaseURL("invalid%x99")},
+			err:  errInvalidDomain,
+		},
+		{
+			name: "WithLogger should set the logger for client and private rate limiter",
+			opts: []Option{WithLogger(logger)},
+			verify: func(api *API) error {
+				if api.logger != logger || api.rateLimits.logger != logger {
+					return errors.New("logger not set")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "WithLogger should reject nil",
+			opts: []Option{WithLogger(nil)},
+			err:  errNilOption,
+		},
+		{
+			name: "WithUserAgent should set the user agent",
+			opts: []Option{WithUserAgent("foo/1.0")},
+			verify: func(api *API) error {
+				if api.userAgent != "foo/1.0" {
+					return errors.New("user agent not set")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "WithMaxRetries should set the max retries",
+			opts: []Option{WithMaxRetries(3)},
+			verify: func(api *API) error {
+				if api.maxRetries != 3 {
+					return errors.New("max retries not set")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "WithMaxRetries should reject negative",
+			opts: []Option{WithMaxRetries(-1)},
+			err:  errNegative,
+		},
+		{
+			name: "WithPageSize should set the page size",
+			opts: []Option{WithPageSize(20)},
+			verify: func(api *API) error {
+				if api.pageSize != 20 {
+					return errors.New("page size not set")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "WithPageSize should reject out of range",
+			opts: []Option{WithPageSize(51)},
+			err:  errPageSize,
+		},
+		{
+			name: "WithRateLimiter should share the limiter",
+			opts: []Option{WithLogger(logger), WithRateLimiter(limiter)},
+			verify: func(api *API) error {
+				if api.rateLimits != limiter {
+					return errors.New("rate limiter not set")
+				}
+
+				if limiter.logger == logger {
+					return errors.New("shared rate limiter logger should not be overridden")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "WithRateLimiter should reject nil",
+			opts: []Option{WithRateLimiter(nil)},
+			err:  errNilOption,
+		},
+		{
+			name: "WithDecodingMode should set the decoding mode",
+			opts: []Option{WithDecodingMode(DecodingStrict)},
+			verify: func(api *API) error {
+				if api.decodingMode != DecodingStrict {
+					return errors.New("decoding mode not set")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "WithDecodingMode should reject unknown modes",
+			opts: []Option{WithDecodingMode(42)},
+			err:  errDecodingMode,
+		},
+		{
+			name: "WithInstrumentsCache should cache the instruments",
+			opts: []Option{WithInstrumentsCache(NewInstrumentsCache())},
+			verify: func(api *API) error {
+				if _, ok := api.Instruments.(*cachedInstruments); !ok {
+					return errors.New("instruments not cached")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "WithInstrumentsCache should reject a cache not built by NewInstrumentsCache",
+			opts: []Option{WithInstrumentsCache(&InstrumentsCache{Store: NewMemoryCacheStore()})},
+			err:  errInstrumentsCache,
+		},
+		{
+			name: "WithOrderPreflight should enforce the pre-flight checks",
+			opts: []Option{WithOrderPreflight()},
+			verify: func(api *API) error {
+				if !api.Orders.(*orders).enforcePreflight {
+					return errors.New("pre-flight not enforced")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "WithSchemaDriftHandler should reject nil",
+			opts: []Option{WithSchemaDriftHandler(nil)},
+			err:  errNilOption,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				api, err := NewAPIDemo("foo", "bar", tt.opts...)
+				if tt.err != nil {
+					if !errors.Is(err, tt.err) || !errors.Is(err, errInvalidOption) {
+						t.Errorf("NewAPIDemo() error = %v, want %v", err, tt.err)
+					}
+
+					return
+				}
+
+				err = validateAPI(api, err)
+				if err != nil {
+					t.Fatalf("NewAPIDemo() returned an unexpected error; %v", err)
+				}
+
+				err = tt.verify(api)
+				if err != nil {
+					t.Errorf("NewAPIDemo() option not applied; %v", err)
+				}
+			},
+		)
+	}
+}
+
+func Test_Options_Request(t *testing.T) {
+	t.Parallel()
+
+	api, err := NewAPIDemo("foo", "bar", WithUserAgent("foo/1.0"), WithPageSize(20), WithMaxRetries(2))
+	if err != nil {
+		t.Fatalf("NewAPIDemo() returned an unexpected error; %v", err)
+	}
+
+	request, err := api.NewRequest(http.MethodGet, GetTransactions, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() returned an unexpected error; %v", err)
+	}
+
+	if got := request.http().Header.Get("User-Agent"); got != "foo/1.0" {
+		t.Errorf("NewRequest() User-Agent = %q, want %q", got, "foo/1.0")
+	}
+
+	if got := request.http().URL.Query().Get("limit"); got != "20" {
+		t.Errorf("NewRequest() limit = %q, want %q", got, "20")
+	}
+
+	if got := request.(*Request).maxRetries; got != 2 {
+		t.Errorf("NewRequest() maxRetries = %d, want %d", got, 2)
+	}
+}
@@ -0,0 +1,715 @@
+package trading212
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+const (
+	// defaultOrderGroupsPollInterval matches the rate-limit of GetPendingOrderByID.
+	defaultOrderGroupsPollInterval = time.Second
+	// orderGroupsKey of the open groups in the store.
+	orderGroupsKey = "order-groups"
+)
+
+var (
+	errOrderGroupTicker   = errors.New("order group legs should share the ticker")
+	errOrderGroupQuantity = errors.New("order group exits should close the entry quantity")
+	errOrderGroupPrices   = errors.New("order group take-profit should be beyond the stop-loss")
+	errUnknownOrderGroup  = errors.New("unknown order group")
+	errOrderGroupUnsaved  = errors.New("order group could not be saved, it is lost on restart")
+)
+
+// OrderGroupState is the progress of an OrderGroup.
+type OrderGroupState string
+
+const (
+	// OrderGroupPending waits for the entry to be filled.
+	OrderGroupPending OrderGroupState = "PENDING"
+	// OrderGroupOpen has its exits placed, and waits for them to close the filled quantity.
+	OrderGroupOpen OrderGroupState = "OPEN"
+	// OrderGroupDone had the filled quantity closed by its exits, the other one cancelled.
+	OrderGroupDone OrderGroupState = "DONE"
+	// OrderGroupCancelled was cancelled, or had its entry done without being filled.
+	OrderGroupCancelled OrderGroupState = "CANCELLED"
+	// OrderGroupFailed could not place an exit, or had one rejected, see OrderGroup.Error.
+	OrderGroupFailed OrderGroupState = "FAILED"
+)
+
+// IsOpen reports whether the group still has orders to follow.
+func (state OrderGroupState) IsOpen() bool {
+	return state == OrderGroupPending || state == OrderGroupOpen
+}
+
+// OrderGroup is an emulated bracket or one-cancels-other order, see OrderGroups.
+type OrderGroup struct {
+	// ID of the group, generated.
+	ID string `json:"id"`
+	// State of the group.
+	State OrderGroupState `json:"state"`
+	// Entry order of a bracket, nil for a one-cancels-other.
+	Entry *models.LimitOrderRequest `json:"entry,omitempty"`
+	// TakeProfit exit, a limit order.
+	TakeProfit models.LimitOrderRequest `json:"takeProfit"`
+	// StopLoss exit, a stop order.
+	StopLoss models.StopOrderRequest `json:"stopLoss"`
+	// EntryID of the placed entry order, 0 for a one-cancels-other.
+	EntryID int64 `json:"entryId,omitempty"`
+	// TakeProfitID of the placed take-profit order, 0 until placed and once done, see Closed.
+	TakeProfitID int64 `json:"takeProfitId,omitempty"`
+	// StopLossID of the placed stop-loss order, 0 until placed and once done, see Closed.
+	StopLossID int64 `json:"stopLossId,omitempty"`
+	// Filled quantity of the entry, positive.
+	Filled models.Quantity `json:"filled"`
+	// Closed quantity by the exits done, positive, the exits being placed for the rest of Filled.
+	Closed models.Quantity `json:"closed"`
+	// ExitID of the exit order that closed the filled quantity, 0 until then.
+	ExitID int64 `json:"exitId,omitempty"`
+	// Error that failed or cancelled the group, or a warning once done, e.g. both exits were filled.
+	Error string `json:"error,omitempty"`
+	// CreatedAt local time.
+	CreatedAt time.Time `json:"createdAt"`
+	// UpdatedAt local time of the last change of state.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// OrderGroups emulates client-side the bracket and one-cancels-other orders, that Trading212 does not support.
+// A bracket places its entry, then its take-profit and stop-loss exits once the entry is done, for the filled
+// quantity. A one-cancels-other places both exits at once. When an exit is filled, the other is cancelled.
+// An exit done without being filled, e.g. expired or cancelled, is placed again for the open quantity, so that
+// the position is never left without its other exit. When it was partially filled, the other is cancelled and
+// both are placed again for the open quantity. A rejected exit fails the group, leaving the other as is.
+//
+// The groups only advance while Sync or Run is called, which do not wait for the orders: a group whose orders
+// are not yet found done, e.g. missing from the historical orders, is left open to the next Sync. The open
+// groups are saved to the store on every change, so a restarted process picks them up. The methods are safe
+// for concurrent use, the requests of each group being serialized.
+type OrderGroups struct {
+	// PollInterval between two Sync of Run.
+	// Defaults to 1 second, the rate-limit of GetPendingOrderByID.
+	PollInterval time.Duration
+
+	// mutex guards the groups, their locks, the searches and the saved data, it is never held during the requests.
+	mutex sync.Mutex
+	// locks serialize the requests of each group, by ID.
+	locks map[string]*sync.Mutex
+	// searches tell when the orders missing from the historical orders may be searched again, by ID.
+	searches map[int64]time.Time
+	orders   ordersOperations
+	tracker  *OrderTracker
+	store    CacheStore
+	logger   *slog.Logger
+	groups   []*OrderGroup
+	// saved data, to skip storing unchanged groups.
+	saved []byte
+}
+
+// NewOrderGroups create the OrderGroups placing the orders with api, and loads the open groups from store.
+// Use a FileCacheStore to keep the groups across restarts.
+func NewOrderGroups(api *API, store CacheStore) (*OrderGroups, error) {
+	groups := &OrderGroups{
+		PollInterval: defaultOrderGroupsPollInterval,
+		mutex:        sync.Mutex{},
+		locks:        map[string]*sync.Mutex{},
+		searches:     map[int64]time.Time{},
+		orders:       api.Orders,
+		tracker:      NewOrderTracker(api),
+		store:        store,
+		logger:       api.logger,
+		groups:       nil,
+		saved:        nil,
+	}
+
+	data, _, err := store.Load(orderGroupsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if data != nil {
+		err = json.Unmarshal(data, &groups.groups)
+		if err != nil {
+			return nil, errors.Join(errCacheStore, err)
+		}
+
+		groups.saved = data
+	}
+
+	return groups, nil
+}
+
+// PlaceBracket places the entry order, and creates the group placing the exits once it is filled.
+// The exits quantity is the opposite of the entry one. A group that could not be saved is returned
+// along with the error, to not lose track of its placed orders.
+func (groups *OrderGroups) PlaceBracket(
+	ctx context.Context, entry models.LimitOrderRequest, takeProfit models.LimitOrderRequest,
+	stopLoss models.StopOrderRequest,
+) (*OrderGroup, error) {
+	return groups.add(ctx, &entry, takeProfit, stopLoss)
+}
+
+// PlaceOCO places both exits of an open position at once, and creates the group cancelling one
+// when the other is filled. The exits have the same quantity. A group that could not be saved, or
+// whose exits could not all be placed, is returned along with the error.
+func (groups *OrderGroups) PlaceOCO(
+	ctx context.Context, takeProfit models.LimitOrderRequest, stopLoss models.StopOrderRequest,
+) (*OrderGroup, error) {
+	return groups.add(ctx, nil, takeProfit, stopLoss)
+}
+
+// Groups returns a copy of the groups, the ones loaded from the store first.
+func (groups *OrderGroups) Groups() []*OrderGroup {
+	groups.mutex.Lock()
+	defer groups.mutex.Unlock()
+
+	copies := make([]*OrderGroup, 0, len(groups.groups))
+	for _, group := range groups.groups {
+		clone := *group
+		copies = append(copies, &clone)
+	}
+
+	return copies
+}
+
+// Sync advances the open groups once, placing the exits of the filled entries, cancelling the exits
+// whose sibling is done and placing again the exits done without being filled. Each order is looked up
+// once, in the pending orders then in the historical orders within their rate-limit, without waiting.
+// The failures are returned, and retried on the next Sync. The groups already followed by another call,
+// e.g. being cancelled, are left to it.
+func (groups *OrderGroups) Sync(ctx context.Context) error {
+	var errs []error
+
+	for _, id := range groups.open() {
+		err := groups.sync(ctx, id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("order group %s: %w", id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run calls Sync every PollInterval until ctx is done, logging its failures, and returns the cause of ctx.
+func (groups *OrderGroups) Run(ctx context.Context) error {
+	for {
+		err := groups.Sync(ctx)
+		if err != nil && ctx.Err() == nil {
+			groups.logger.Warn("Fail to sync order groups", "error", err)
+		}
+
+		err = sleepContext(ctx, groups.PollInterval)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Cancel cancels the placed orders of the group, waiting for their cancellation to be confirmed.
+// Cancelling a closed group does nothing. The entry of a bracket may have been partially filled,
+// see OrderGroup.Filled, as the exits, see OrderGroup.Closed.
+func (groups *OrderGroups) Cancel(ctx context.Context, id string) error {
+	lock, found := groups.lock(id)
+	if !found {
+		return fmt.Errorf("%w: %s", errUnknownOrderGroup, id)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	group := groups.group(id)
+	if !group.State.IsOpen() {
+		return nil
+	}
+
+	for _, orderID := range []int64{group.EntryID, group.TakeProfitID, group.StopLossID} {
+		if orderID == 0 {
+			continue
+		}
+
+		final, err := groups.cancelLeg(ctx, group, orderID)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case orderID == group.EntryID && group.State == OrderGroupPending:
+			group.Filled = filledQuantity(final)
+		case orderID != group.EntryID:
+			group.Closed = group.Closed.Add(filledQuantity(final))
+		}
+
+		groups.searched(orderID, 0)
+	}
+
+	group.close(OrderGroupCancelled, "")
+
+	return groups.commit(group)
+}
+
+func (groups *OrderGroups) add(
+	ctx context.Context, entry *models.LimitOrderRequest, takeProfit models.LimitOrderRequest,
+	stopLoss models.StopOrderRequest,
+) (*OrderGroup, error) {
+	now := time.Now()
+	group := &OrderGroup{
+		ID: "", State: OrderGroupPending, Entry: entry, TakeProfit: takeProfit, StopLoss: stopLoss,
+		EntryID: 0, TakeProfitID: 0, StopLossID: 0, Filled: models.Quantity{}, Closed: models.Quantity{},
+		ExitID: 0, Error: "", CreatedAt: now, UpdatedAt: now,
+	}
+
+	err := group.validate()
+	if err != nil {
+		return nil, errors.Join(errInvalidRequest, err)
+	}
+
+	group.ID, err = newOrderGroupID()
+	if err != nil {
+		return nil, err
+	}
+
+	if entry != nil {
+		order, err := groups.orders.PlaceLimitOrderWithContext(ctx, *entry)
+		if err != nil {
+			return nil, err
+		}
+
+		group.EntryID = orderID(order)
+	} else {
+		group.Filled = takeProfit.Quantity.Abs()
+		group.update(OrderGroupOpen)
+	}
+
+	// locked before being listed, so that Sync leaves the exits to place to this call
+	lock := &sync.Mutex{}
+	lock.Lock()
+	defer lock.Unlock()
+
+	groups.mutex.Lock()
+	groups.locks[group.ID] = lock
+	groups.mutex.Unlock()
+
+	err = groups.commit(group)
+	if err == nil && group.State == OrderGroupOpen {
+		err = groups.commitAfter(group, groups.placeExits(ctx, group))
+	}
+
+	clone := *group
+
+	return &clone, err
+}
+
+// open returns the IDs of the open groups.
+func (groups *OrderGroups) open() []string {
+	groups.mutex.Lock()
+	defer groups.mutex.Unlock()
+
+	var ids []string
+
+	for _, group := range groups.groups {
+		if group.State.IsOpen() {
+			ids = append(ids, group.ID)
+		}
+	}
+
+	return ids
+}
+
+// sync advances a copy of the group, then commits it, unless another call follows the group.
+func (groups *OrderGroups) sync(ctx context.Context, id string) error {
+	lock, _ := groups.lock(id)
+	if !lock.TryLock() {
+		return nil
+	}
+	defer lock.Unlock()
+
+	group := groups.group(id)
+	if !group.State.IsOpen() {
+		return nil
+	}
+
+	return groups.commitAfter(group, groups.advance(ctx, group))
+}
+
+// lock returns the lock of the group, created for the groups loaded from the store.
+func (groups *OrderGroups) lock(id string) (*sync.Mutex, bool) {
+	groups.mutex.Lock()
+	defer groups.mutex.Unlock()
+
+	if !slices.ContainsFunc(groups.groups, func(group *OrderGroup) bool { return group.ID == id }) {
+		return nil, false
+	}
+
+	lock, found := groups.locks[id]
+	if !found {
+		lock = &sync.Mutex{}
+		groups.locks[id] = lock
+	}
+
+	return lock, true
+}
+
+// group returns a copy of the listed group, to change while holding its lock.
+func (groups *OrderGroups) group(id string) *OrderGroup {
+	groups.mutex.Lock()
+	defer groups.mutex.Unlock()
+
+	index := slices.IndexFunc(groups.groups, func(group *OrderGroup) bool { return group.ID == id })
+	clone := *groups.groups[index]
+
+	return &clone
+}
+
+// commit lists a copy of the group in place of the previous one, and saves the open groups.
+func (groups *OrderGroups) commit(group *OrderGroup) error {
+	groups.mutex.Lock()
+	defer groups.mutex.Unlock()
+
+	clone := *group
+
+	index := slices.IndexFunc(groups.groups, func(listed *OrderGroup) bool { return listed.ID == group.ID })
+	if index < 0 {
+		groups.groups = append(groups.groups, &clone)
+	} else {
+		groups.groups[index] = &clone
+	}
+
+	err := groups.save()
+	if err != nil {
+		return fmt.Errorf("%w: group %s, entry %d, take-profit %d, stop-loss %d: %w", errOrderGroupUnsaved,
+			group.ID, group.EntryID, group.TakeProfitID, group.StopLossID, err)
+	}
+
+	return nil
+}
+
+// commitAfter commits the group changed by a call failing with err, unless that commit already failed.
+func (groups *OrderGroups) commitAfter(group *OrderGroup, err error) error {
+	if errors.Is(err, errOrderGroupUnsaved) {
+		return err
+	}
+
+	return errors.Join(err, groups.commit(group))
+}
+
+// advance the group as far as its orders allow.
+func (groups *OrderGroups) advance(ctx context.Context, group *OrderGroup) error {
+	if group.State == OrderGroupPending {
+		final, done, err := groups.terminal(ctx, group, group.EntryID)
+		if err != nil || !done {
+			return err
+		}
+
+		group.Filled = filledQuantity(final)
+		if group.Filled.IsZero() {
+			group.close(OrderGroupCancelled, fmt.Sprintf("entry %s without being filled", final.Order.Status))
+
+			return nil
+		}
+
+		group.update(OrderGroupOpen)
+	}
+
+	if group.State != OrderGroupOpen {
+		return nil
+	}
+
+	err := groups.checkExits(ctx, group)
+	if err != nil || group.State != OrderGroupOpen {
+		return err
+	}
+
+	open := group.open()
+	if open.Sign() > 0 {
+		return groups.placeExits(ctx, group)
+	}
+
+	// closed once the other exit is confirmed done, to tell whether it was filled too
+	if group.TakeProfitID == 0 && group.StopLossID == 0 {
+		message := ""
+		if open.Sign() < 0 {
+			message = "both exits were filled"
+		}
+
+		group.close(OrderGroupDone, message)
+	}
+
+	return nil
+}
+
+// placeExits places the missing exits for the open quantity, saving each of them once placed.
+func (groups *OrderGroups) placeExits(ctx context.Context, group *OrderGroup) error {
+	takeProfit, stopLoss := group.exits()
+
+	if group.TakeProfitID == 0 {
+		order, err := groups.orders.PlaceLimitOrderWithContext(ctx, takeProfit)
+		if err != nil {
+			return groups.fail(ctx, group, "take-profit", err)
+		}
+
+		group.TakeProfitID = orderID(order)
+
+		err = groups.commit(group)
+		if err != nil {
+			return err
+		}
+	}
+
+	if group.StopLossID == 0 {
+		order, err := groups.orders.PlaceStopOrderWithContext(ctx, stopLoss)
+		if err != nil {
+			return groups.fail(ctx, group, "stop-loss", err)
+		}
+
+		group.StopLossID = orderID(order)
+
+		return groups.commit(group)
+	}
+
+	return nil
+}
+
+// fail the group when placing an exit is refused or its outcome unknown, the placed orders being left as is.
+// The transient failures are only returned, to be retried.
+func (groups *OrderGroups) fail(ctx context.Context, group *OrderGroup, leg string, err error) error {
+	if IsRetryable(err) || ctx.Err() != nil {
+		return err
+	}
+
+	group.close(OrderGroupFailed, fmt.Sprintf("placing the %s: %v", leg, err))
+
+	return err
+}
+
+// checkExits follows the placed exits, closing the quantity of the ones done. The other exit is cancelled
+// once the filled quantity is closed, or once the exit was partially filled, to be placed again for the open
+// quantity along with it. The cancelled exit is followed until confirmed done, as it may have been filled.
+func (groups *OrderGroups) checkExits(ctx context.Context, group *OrderGroup) error {
+	legs := [][2]*int64{{&group.TakeProfitID, &group.StopLossID}, {&group.StopLossID, &group.TakeProfitID}}
+
+	for _, leg := range legs {
+		exit, other := leg[0], leg[1]
+		if *exit == 0 {
+			continue
+		}
+
+		final, done, err := groups.terminal(ctx, group, *exit)
+		if err != nil {
+			return err
+		}
+
+		if !done {
+			continue
+		}
+
+		filled := filledQuantity(final)
+		open := group.open().Sub(filled)
+
+		if final.Order.Status == models.OrderStatusRejected && open.Sign() > 0 {
+			group.Closed = group.Closed.Add(filled)
+			group.close(OrderGroupFailed, fmt.Sprintf("exit %d %s", *exit, final.Order.Status))
+
+			return nil
+		}
+
+		if *other != 0 && (open.Sign() <= 0 || !filled.IsZero()) {
+			// not found once done, followed as the other exit
+			err = groups.orders.CancelOrderWithContext(ctx, *other)
+			if err != nil && !IsNotFound(err) {
+				return err
+			}
+		}
+
+		if open.Sign() > 0 {
+			groups.logger.Warn("Order group exit done without being filled, placing it again", "group", group.ID,
+				"order", *exit, "status", final.Order.Status, "filled", filled, "open", open)
+		} else if !filled.IsZero() && group.ExitID == 0 {
+			group.ExitID = *exit
+		}
+
+		group.Closed = group.Closed.Add(filled)
+		*exit = 0
+	}
+
+	return nil
+}
+
+// terminal returns the final state of the order of the group once no longer pending, looking it up once.
+// An order missing from the historical orders is searched again on a later call, once the rate-limit allows.
+func (groups *OrderGroups) terminal(ctx context.Context, group *OrderGroup, id int64) (*TrackedOrder, bool, error) {
+	order, err := groups.orders.GetPendingOrderByIDWithContext(ctx, id)
+	if err == nil && !order.Status.IsTerminal() {
+		return nil, false, nil
+	}
+
+	if err != nil && !IsNotFound(err) {
+		return nil, false, err
+	}
+
+	if err != nil {
+		order = group.leg(id)
+	}
+
+	if time.Now().Before(groups.searchAt(id)) {
+		return nil, false, nil
+	}
+
+	final, delay, err := groups.tracker.search(ctx, order)
+	groups.searched(id, delay)
+
+	return final, final != nil, err
+}
+
+// searchAt returns when the order may be searched in the historical orders, zero when not yet searched.
+func (groups *OrderGroups) searchAt(id int64) time.Time {
+	groups.mutex.Lock()
+	defer groups.mutex.Unlock()
+
+	return groups.searches[id]
+}
+
+// searched records the delay before searching the order again, forgetting it when 0.
+func (groups *OrderGroups) searched(id int64, delay time.Duration) {
+	groups.mutex.Lock()
+	defer groups.mutex.Unlock()
+
+	if delay == 0 {
+		delete(groups.searches, id)
+
+		return
+	}
+
+	groups.searches[id] = time.Now().Add(delay)
+}
+
+// cancelLeg cancels the order of the group, and returns its confirmed final state.
+func (groups *OrderGroups) cancelLeg(ctx context.Context, group *OrderGroup, id int64) (*TrackedOrder, error) {
+	// not found once done, the final state tells
+	err := groups.orders.CancelOrderWithContext(ctx, id)
+	if err != nil && !IsNotFound(err) {
+		return nil, err
+	}
+
+	return groups.tracker.WaitForTerminalOf(ctx, group.leg(id))
+}
+
+// save the open groups to the store, when they changed.
+func (groups *OrderGroups) save() error {
+	open := []*OrderGroup{}
+
+	for _, group := range groups.groups {
+		if group.State.IsOpen() {
+			open = append(open, group)
+		}
+	}
+
+	data, err := json.Marshal(open)
+	if err != nil {
+		return errors.Join(errCacheStore, err)
+	}
+
+	if bytes.Equal(data, groups.saved) {
+		return nil
+	}
+
+	err = groups.store.Store(orderGroupsKey, data, time.Now())
+	if err != nil {
+		return err
+	}
+
+	groups.saved = data
+
+	return nil
+}
+
+// validate the legs of the group against each other.
+func (group *OrderGroup) validate() error {
+	errs := []error{group.TakeProfit.Validate(), group.StopLoss.Validate()}
+
+	ticker, quantity := group.TakeProfit.Ticker, group.TakeProfit.Quantity
+	if group.Entry != nil {
+		errs = append(errs, group.Entry.Validate())
+		ticker, quantity = group.Entry.Ticker, group.Entry.Quantity.Neg()
+	}
+
+	if group.TakeProfit.Ticker != ticker || group.StopLoss.Ticker != ticker {
+		errs = append(errs, fmt.Errorf("%w: got take-profit %s, stop-loss %s, want %s",
+			errOrderGroupTicker, group.TakeProfit.Ticker, group.StopLoss.Ticker, ticker))
+	}
+
+	if !group.TakeProfit.Quantity.Equal(quantity) || !group.StopLoss.Quantity.Equal(quantity) {
+		errs = append(errs, fmt.Errorf("%w: got take-profit %v, stop-loss %v, want %v",
+			errOrderGroupQuantity, group.TakeProfit.Quantity, group.StopLoss.Quantity, quantity))
+	}
+
+	// a sell exit takes profit above the stop, a buy exit below it
+	compared := group.TakeProfit.LimitPrice.Cmp(group.StopLoss.StopPrice)
+	if (quantity.Sign() < 0 && compared <= 0) || (quantity.Sign() > 0 && compared >= 0) {
+		errs = append(errs, fmt.Errorf("%w: got take-profit %v, stop-loss %v",
+			errOrderGroupPrices, group.TakeProfit.LimitPrice, group.StopLoss.StopPrice))
+	}
+
+	return errors.Join(errs...)
+}
+
+// exits returns the exit requests for the open quantity.
+func (group *OrderGroup) exits() (models.LimitOrderRequest, models.StopOrderRequest) {
+	takeProfit, stopLoss := group.TakeProfit, group.StopLoss
+
+	quantity := group.open()
+	if takeProfit.Quantity.Sign() < 0 {
+		quantity = quantity.Neg()
+	}
+
+	takeProfit.Quantity, stopLoss.Quantity = quantity, quantity
+
+	return takeProfit, stopLoss
+}
+
+// leg returns what is known of an order of the group, to narrow the search of its final state:
+// its ticker, and a creation time no later than the group.
+func (group *OrderGroup) leg(id int64) *models.Order {
+	order := &models.Order{}
+	order.ID = uint(id) //nolint:gosec // ids are positive
+	order.Ticker, order.CreatedAt = group.TakeProfit.Ticker, group.CreatedAt
+
+	return order
+}
+
+// open returns the filled quantity not yet closed by the exits, negative when they closed more.
+func (group *OrderGroup) open() models.Quantity {
+	return group.Filled.Sub(group.Closed)
+}
+
+func (group *OrderGroup) update(state OrderGroupState) {
+	group.State, group.UpdatedAt = state, time.Now()
+}
+
+func (group *OrderGroup) close(state OrderGroupState, message string) {
+	group.update(state)
+	group.Error = message
+}
+
+func orderID(order *models.Order) int64 {
+	return int64(order.ID) //nolint:gosec // ids fit in int64
+}
+
+func newOrderGroupID() (string, error) {
+	var id [8]byte
+
+	_, err := rand.Read(id[:])
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id[:]), nil
+}
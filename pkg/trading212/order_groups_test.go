@@ -0,0 +1,515 @@
+package trading212
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+// fakeExchange keeps the placed orders, pending until filled or cancelled by the test.
+type fakeExchange struct {
+	mutex  sync.Mutex
+	orders []*fakeOrder
+}
+
+type fakeOrder struct {
+	ID        int64       `json:"id"`
+	Ticker    string      `json:"ticker"`
+	Type      string      `json:"type"`
+	Quantity  json.Number `json:"quantity"`
+	Status    string      `json:"status"`
+	CreatedAt time.Time   `json:"createdAt"`
+	filled    json.Number
+}
+
+func (exchange *fakeExchange) routes() map[APIEndpoint]http.HandlerFunc {
+	return map[APIEndpoint]http.HandlerFunc{
+		http.MethodPost + " " + PlaceLimitOrder:              exchange.place("LIMIT"),
+		http.MethodPost + " " + PlaceStopOrder:               exchange.place("STOP"),
+		http.MethodGet + " " + GetHistoricalOrders:           exchange.history,
+		http.MethodGet + " " + GetPendingOrderByID + "/{id}": exchange.pending,
+		http.MethodDelete + " " + CancelOrder + "/{id}":      exchange.pending,
+	}
+}
+
+func (exchange *fakeExchange) place(orderType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		exchange.mutex.Lock()
+		defer exchange.mutex.Unlock()
+
+		var order fakeOrder
+
+		_ = json.NewDecoder(r.Body).Decode(&order)
+		order.ID, order.Status, order.CreatedAt = int64(100+len(exchange.orders)), "NEW", time.Now().UTC()
+		order.Type = orderType
+		exchange.orders = append(exchange.orders, &order)
+
+		_ = json.NewEncoder(w).Encode(order)
+	}
+}
+
+func (exchange *fakeExchange) history(w http.ResponseWriter, _ *http.Request) {
+	exchange.mutex.Lock()
+	defer exchange.mutex.Unlock()
+
+	items := []string{}
+
+	for _, order := range slices.Backward(exchange.orders) {
+		if order.Status != "NEW" {
+			data, _ := json.Marshal(order)
+			items = append(items, fmt.Sprintf(`{"order": %s, "fill": {"id": %d, "quantity": %s}}`,
+				data, order.ID, orZero(order.filled)))
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, `{"items": [%s], "nextPagePath": null}`, strings.Join(items, ", "))
+}
+
+// pending serves a pending order, or cancels it.
+func (exchange *fakeExchange) pending(w http.ResponseWriter, r *http.Request) {
+	exchange.mutex.Lock()
+	defer exchange.mutex.Unlock()
+
+	order := exchange.find(r.PathValue("id"))
+	if order == nil || order.Status != "NEW" {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		order.Status = "CANCELLED"
+
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(order)
+}
+
+func (exchange *fakeExchange) find(id string) *fakeOrder {
+	for _, order := range exchange.orders {
+		if strconv.FormatInt(order.ID, 10) == id {
+			return order
+		}
+	}
+
+	return nil
+}
+
+// fill the order, or cancel it when quantity is "0".
+func (exchange *fakeExchange) fill(id int64, quantity string) {
+	status := "FILLED"
+	if quantity == "0" {
+		status = "CANCELLED"
+	}
+
+	exchange.finish(id, status, quantity)
+}
+
+// finish the order with status, once quantity was filled.
+func (exchange *fakeExchange) finish(id int64, status string, quantity string) {
+	exchange.mutex.Lock()
+	defer exchange.mutex.Unlock()
+
+	order := exchange.find(strconv.FormatInt(id, 10))
+	order.Status, order.filled = status, json.Number(quantity)
+}
+
+func (exchange *fakeExchange) statuses() string {
+	exchange.mutex.Lock()
+	defer exchange.mutex.Unlock()
+
+	statuses := []string{}
+	for _, order := range exchange.orders {
+		statuses = append(statuses, fmt.Sprintf("%d %s %s %s", order.ID, order.Type, order.Quantity, order.Status))
+	}
+
+	return strings.Join(statuses, ", ")
+}
+
+func orZero(number json.Number) string {
+	if number == "" {
+		return "0"
+	}
+
+	return string(number)
+}
+
+// failingStore has nothing stored, and fails to store.
+type failingStore struct{}
+
+func (failingStore) Load(string) ([]byte, time.Time, error) {
+	return nil, time.Time{}, nil
+}
+
+func (failingStore) Store(string, []byte, time.Time) error {
+	return errCacheStore
+}
+
+func newOrderGroupsMock(t *testing.T, routes map[APIEndpoint]http.HandlerFunc, store CacheStore) *OrderGroups {
+	t.Helper()
+
+	groups, err := NewOrderGroups(newRoutesMockAPI(t, routes), store)
+	if err != nil {
+		t.Fatalf("NewOrderGroups() error = %v", err)
+	}
+
+	groups.tracker.PollInterval = time.Millisecond
+	groups.tracker.HistoryInterval = time.Millisecond
+
+	return groups
+}
+
+func newBracketRequests(
+	entry, takeProfit, stopLoss int64,
+) (models.LimitOrderRequest, models.LimitOrderRequest, models.StopOrderRequest) {
+	entryReq := models.LimitOrderRequest{}
+	entryReq.Ticker, entryReq.Quantity, entryReq.LimitPrice = "AAPL_US_EQ", models.DecimalFromInt(10),
+		models.DecimalFromInt(entry)
+
+	takeProfitReq := models.LimitOrderRequest{}
+	takeProfitReq.Ticker, takeProfitReq.Quantity, takeProfitReq.LimitPrice = "AAPL_US_EQ", models.DecimalFromInt(-10),
+		models.DecimalFromInt(takeProfit)
+
+	stopLossReq := models.StopOrderRequest{}
+	stopLossReq.Ticker, stopLossReq.Quantity, stopLossReq.StopPrice = "AAPL_US_EQ", models.DecimalFromInt(-10),
+		models.DecimalFromInt(stopLoss)
+
+	return entryReq, takeProfitReq, stopLossReq
+}
+
+func Test_OrderGroups_Bracket(t *testing.T) {
+	t.Parallel()
+
+	exchange := &fakeExchange{mutex: sync.Mutex{}, orders: nil}
+
+	store, err := NewFileCacheStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCacheStore() error = %v", err)
+	}
+
+	groups := newOrderGroupsMock(t, exchange.routes(), store)
+
+	entry, takeProfit, stopLoss := newBracketRequests(100, 120, 90)
+
+	group, err := groups.PlaceBracket(context.Background(), entry, takeProfit, stopLoss)
+	if err != nil || group.State != OrderGroupPending || group.EntryID != 100 {
+		t.Fatalf("PlaceBracket() = %+v, %v, want a pending group", group, err)
+	}
+
+	err = groups.Sync(context.Background())
+	if err != nil || exchange.statuses() != "100 LIMIT 10 NEW" {
+		t.Fatalf("Sync() error = %v, orders %s, want the entry only", err, exchange.statuses())
+	}
+
+	exchange.fill(100, "6")
+
+	err = groups.Sync(context.Background())
+	if want := "100 LIMIT 10 FILLED, 101 LIMIT -6 NEW, 102 STOP -6 NEW"; err != nil || exchange.statuses() != want {
+		t.Fatalf("Sync() error = %v, orders %s, want %s", err, exchange.statuses(), want)
+	}
+
+	// a restarted process picks up the open group
+	restarted := newOrderGroupsMock(t, exchange.routes(), store)
+	if loaded := restarted.Groups(); len(loaded) != 1 || loaded[0].State != OrderGroupOpen {
+		t.Fatalf("Groups() = %+v, want the open group", loaded)
+	}
+
+	exchange.fill(102, "-6")
+
+	// the take-profit, looked up first, is confirmed cancelled on the next Sync
+	err = restarted.Sync(context.Background())
+	if err != nil || restarted.Groups()[0].State != OrderGroupOpen {
+		t.Fatalf("Sync() = %+v, %v, want the group open", restarted.Groups()[0], err)
+	}
+
+	err = restarted.Sync(context.Background())
+	if want := "100 LIMIT 10 FILLED, 101 LIMIT -6 CANCELLED, 102 STOP -6 FILLED"; err != nil ||
+		exchange.statuses() != want {
+		t.Fatalf("Sync() error = %v, orders %s, want %s", err, exchange.statuses(), want)
+	}
+
+	done := restarted.Groups()[0]
+	if done.State != OrderGroupDone || done.ExitID != 102 || done.Filled.String() != "6" || done.Error != "" {
+		t.Errorf("Groups() = %+v, want done by the stop-loss", done)
+	}
+
+	if loaded := newOrderGroupsMock(t, exchange.routes(), store).Groups(); len(loaded) != 0 {
+		t.Errorf("Groups() = %+v, want no open group left", loaded)
+	}
+}
+
+func Test_OrderGroups(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		prices       [3]int64
+		oco          bool
+		events       func(exchange *fakeExchange, groups *OrderGroups, id string) error
+		wantState    OrderGroupState
+		wantStatuses string
+		wantErr      error
+	}{
+		{
+			name:   "take-profit cancels the stop-loss",
+			prices: [3]int64{0, 120, 90},
+			oco:    true,
+			events: func(exchange *fakeExchange, _ *OrderGroups, _ string) error {
+				exchange.fill(100, "-10")
+
+				return nil
+			},
+			wantState:    OrderGroupDone,
+			wantStatuses: "100 LIMIT -10 FILLED, 101 STOP -10 CANCELLED",
+			wantErr:      nil,
+		},
+		{
+			name:   "expired take-profit is placed again",
+			prices: [3]int64{0, 120, 90},
+			oco:    true,
+			events: func(exchange *fakeExchange, _ *OrderGroups, _ string) error {
+				exchange.fill(100, "0")
+
+				return nil
+			},
+			wantState:    OrderGroupOpen,
+			wantStatuses: "100 LIMIT -10 CANCELLED, 101 STOP -10 NEW, 102 LIMIT -10 NEW",
+			wantErr:      nil,
+		},
+		{
+			name:   "partially filled take-profit places both exits again",
+			prices: [3]int64{0, 120, 90},
+			oco:    true,
+			events: func(exchange *fakeExchange, _ *OrderGroups, _ string) error {
+				exchange.finish(100, "CANCELLED", "-4")
+
+				return nil
+			},
+			wantState:    OrderGroupOpen,
+			wantStatuses: "100 LIMIT -10 CANCELLED, 101 STOP -10 CANCELLED, 102 LIMIT -6 NEW, 103 STOP -6 NEW",
+			wantErr:      nil,
+		},
+		{
+			name:   "rejected stop-loss fails the group",
+			prices: [3]int64{0, 120, 90},
+			oco:    true,
+			events: func(exchange *fakeExchange, _ *OrderGroups, _ string) error {
+				exchange.finish(101, "REJECTED", "0")
+
+				return nil
+			},
+			wantState:    OrderGroupFailed,
+			wantStatuses: "100 LIMIT -10 NEW, 101 STOP -10 REJECTED",
+			wantErr:      nil,
+		},
+		{
+			name:   "entry cancelled without fill",
+			prices: [3]int64{100, 120, 90},
+			oco:    false,
+			events: func(exchange *fakeExchange, _ *OrderGroups, _ string) error {
+				exchange.fill(100, "0")
+
+				return nil
+			},
+			wantState:    OrderGroupCancelled,
+			wantStatuses: "100 LIMIT 10 CANCELLED",
+			wantErr:      nil,
+		},
+		{
+			name:   "group cancelled",
+			prices: [3]int64{0, 120, 90},
+			oco:    true,
+			events: func(_ *fakeExchange, groups *OrderGroups, id string) error {
+				return groups.Cancel(context.Background(), id)
+			},
+			wantState:    OrderGroupCancelled,
+			wantStatuses: "100 LIMIT -10 CANCELLED, 101 STOP -10 CANCELLED",
+			wantErr:      nil,
+		},
+		{
+			name:         "take-profit below the stop-loss",
+			prices:       [3]int64{100, 90, 120},
+			oco:          false,
+			events:       nil,
+			wantState:    "",
+			wantStatuses: "",
+			wantErr:      errOrderGroupPrices,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			exchange := &fakeExchange{mutex: sync.Mutex{}, orders: nil}
+			groups := newOrderGroupsMock(t, exchange.routes(), NewMemoryCacheStore())
+			entry, takeProfit, stopLoss := newBracketRequests(tt.prices[0], tt.prices[1], tt.prices[2])
+
+			var (
+				group *OrderGroup
+				err   error
+			)
+
+			if tt.oco {
+				group, err = groups.PlaceOCO(context.Background(), takeProfit, stopLoss)
+			} else {
+				group, err = groups.PlaceBracket(context.Background(), entry, takeProfit, stopLoss)
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Place() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.events == nil {
+				return
+			}
+
+			err = tt.events(exchange, groups, group.ID)
+			if err == nil {
+				err = groups.Sync(context.Background())
+			}
+
+			if err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+
+			if state := groups.Groups()[0].State; state != tt.wantState || exchange.statuses() != tt.wantStatuses {
+				t.Errorf("Sync() = %s, orders %s, want %s, %s", state, exchange.statuses(), tt.wantState,
+					tt.wantStatuses)
+			}
+		})
+	}
+}
+
+func Test_OrderGroups_Missing(t *testing.T) {
+	t.Parallel()
+
+	exchange := &fakeExchange{mutex: sync.Mutex{}, orders: nil}
+
+	var searched atomic.Int32
+
+	// the done orders are missing from the history
+	routes := exchange.routes()
+	routes[http.MethodGet+" "+GetHistoricalOrders] = func(w http.ResponseWriter, _ *http.Request) {
+		searched.Add(1)
+
+		_, _ = w.Write([]byte(`{"items": [], "nextPagePath": null}`))
+	}
+
+	groups := newOrderGroupsMock(t, routes, NewMemoryCacheStore())
+	groups.tracker.HistoryInterval = time.Hour
+	_, takeProfit, stopLoss := newBracketRequests(0, 120, 90)
+
+	_, err := groups.PlaceOCO(context.Background(), takeProfit, stopLoss)
+	if err != nil {
+		t.Fatalf("PlaceOCO() error = %v", err)
+	}
+
+	exchange.fill(100, "-10")
+
+	for range 2 {
+		err = groups.Sync(context.Background())
+		if err != nil || groups.Groups()[0].State != OrderGroupOpen {
+			t.Fatalf("Sync() = %+v, %v, want the group left open", groups.Groups()[0], err)
+		}
+	}
+
+	if searched.Load() != 1 {
+		t.Errorf("Sync() searched the history %d times, want once within its interval", searched.Load())
+	}
+}
+
+func Test_OrderGroups_Concurrency(t *testing.T) {
+	t.Parallel()
+
+	exchange := &fakeExchange{mutex: sync.Mutex{}, orders: nil}
+	reached, gate := make(chan struct{}), make(chan struct{})
+
+	var (
+		once     sync.Once
+		timedOut bool
+	)
+
+	// the entry of the bracket hangs, the other orders answer
+	routes := exchange.routes()
+	routes[http.MethodGet+" "+GetPendingOrderByID+"/{id}"] = func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "100" {
+			once.Do(func() { close(reached) })
+
+			select {
+			case <-gate:
+			case <-time.After(time.Second):
+				timedOut = true
+			}
+		}
+
+		exchange.pending(w, r)
+	}
+
+	groups := newOrderGroupsMock(t, routes, NewMemoryCacheStore())
+	entry, takeProfit, stopLoss := newBracketRequests(100, 120, 90)
+
+	_, err := groups.PlaceBracket(context.Background(), entry, takeProfit, stopLoss)
+	if err != nil {
+		t.Fatalf("PlaceBracket() error = %v", err)
+	}
+
+	synced := make(chan error)
+
+	go func() { synced <- groups.Sync(context.Background()) }()
+
+	<-reached
+
+	if loaded := groups.Groups(); len(loaded) != 1 {
+		t.Errorf("Groups() = %+v, want the bracket", loaded)
+	}
+
+	oco, err := groups.PlaceOCO(context.Background(), takeProfit, stopLoss)
+	if err != nil {
+		t.Fatalf("PlaceOCO() error = %v", err)
+	}
+
+	err = groups.Cancel(context.Background(), oco.ID)
+	if err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+
+	close(gate)
+
+	err = <-synced
+	if err != nil || timedOut {
+		t.Errorf("Sync() error = %v, timed out %t, want the other groups followed meanwhile", err, timedOut)
+	}
+
+	if want := "100 LIMIT 10 NEW, 101 LIMIT -10 CANCELLED, 102 STOP -10 CANCELLED"; exchange.statuses() != want {
+		t.Errorf("orders %s, want %s", exchange.statuses(), want)
+	}
+}
+
+func Test_OrderGroups_Unsaved(t *testing.T) {
+	t.Parallel()
+
+	exchange := &fakeExchange{mutex: sync.Mutex{}, orders: nil}
+	groups := newOrderGroupsMock(t, exchange.routes(), failingStore{})
+	entry, takeProfit, stopLoss := newBracketRequests(100, 120, 90)
+
+	group, err := groups.PlaceBracket(context.Background(), entry, takeProfit, stopLoss)
+	if !errors.Is(err, errOrderGroupUnsaved) || group == nil || group.EntryID != 100 {
+		t.Fatalf("PlaceBracket() = %+v, %v, want the group with its entry and %v", group, err, errOrderGroupUnsaved)
+	}
+
+	if !strings.Contains(err.Error(), "entry 100") {
+		t.Errorf("PlaceBracket() error = %v, want the entry ID", err)
+	}
+}
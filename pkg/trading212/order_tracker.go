@@ -0,0 +1,324 @@
+package trading212
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+const (
+	// defaultTrackerPollInterval matches the rate-limit of GetPendingOrderByID.
+	defaultTrackerPollInterval = time.Second
+	// defaultTrackerHistoryInterval per page read matches the rate-limit of GetHistoricalOrders, 6 per minute.
+	defaultTrackerHistoryInterval = 10 * time.Second
+	// defaultTrackerHistorySearches gives the historical orders at least 5 minutes to list a done order.
+	defaultTrackerHistorySearches = 30
+	// defaultTrackerHistoryPages bounds each search of the historical orders.
+	defaultTrackerHistoryPages = 5
+)
+
+var (
+	errOrderNotFilled = errors.New("order is done without being filled")
+	errOrderNotFound  = errors.New("order is neither pending nor in the historical orders")
+)
+
+// OrderTracker follows placed orders until they are done. It polls an order while it is pending,
+// then searches the historical orders for its final status and fills once it left the pending list.
+type OrderTracker struct {
+	// PollInterval between two checks of a pending order.
+	// Defaults to 1 second, the rate-limit of GetPendingOrderByID.
+	PollInterval time.Duration
+	// HistoryInterval waited for each page read by a search of the historical orders, before the next
+	// search, while the order is not there yet. Defaults to 10 seconds, the rate-limit of GetHistoricalOrders.
+	HistoryInterval time.Duration
+	// HistorySearches of the historical orders before giving up on an order missing from them.
+	// Defaults to 30, at least 5 minutes at the default HistoryInterval.
+	HistorySearches int
+	// HistoryPages read by each search of the historical orders, the most recent first.
+	// Defaults to 5. The search of a known order stops at the pages older than the order.
+	HistoryPages int
+
+	pending operationGetPendingOrderByID
+	history operationGetHistoricalOrders
+}
+
+// NewOrderTracker create an OrderTracker with the default intervals.
+func NewOrderTracker(api *API) *OrderTracker {
+	return newOrderTracker(api.Orders, api.HistoricalEvents)
+}
+
+func newOrderTracker(pending operationGetPendingOrderByID, history operationGetHistoricalOrders) *OrderTracker {
+	return &OrderTracker{
+		PollInterval:    defaultTrackerPollInterval,
+		HistoryInterval: defaultTrackerHistoryInterval,
+		HistorySearches: defaultTrackerHistorySearches,
+		HistoryPages:    defaultTrackerHistoryPages,
+		pending:         pending,
+		history:         history,
+	}
+}
+
+// OrderTransition is a change of status of a tracked order.
+type OrderTransition struct {
+	// From status, empty for the first status seen.
+	From models.OrderStatus
+	// To status.
+	To models.OrderStatus
+	// Order as last seen.
+	Order *models.Order
+	// At local time the change was seen.
+	At time.Time
+	// Fills of the order, only set on the last transition, once the order is done.
+	Fills []*models.OrderFill
+}
+
+// TrackedOrder is the final state of a tracked order.
+type TrackedOrder struct {
+	// Order as found in the historical orders.
+	Order *models.Order
+	// Fills of the order, the oldest first, empty when nothing was filled.
+	Fills []*models.OrderFill
+}
+
+// Transitions yields the changes of status of the order, e.g. NEW, then PARTIALLY_FILLED, then FILLED,
+// until the order is done. The last transition carries the fills, and is yielded even when the status
+// did not change. A failure, including the context being done, is yielded last.
+// Prefer TransitionsOf for a placed order, an unknown order is searched in the whole history.
+func (tracker *OrderTracker) Transitions(ctx context.Context, id int64) iter.Seq2[*OrderTransition, error] {
+	return tracker.transitions(ctx, &orderWatch{tracker: tracker, id: id, last: nil, searches: 0, pages: 0})
+}
+
+// TransitionsOf is Transitions of a known order, e.g. as placed, whose status is the starting point.
+// Its ticker and creation time narrow the search of the historical orders.
+func (tracker *OrderTracker) TransitionsOf(
+	ctx context.Context, order *models.Order,
+) iter.Seq2[*OrderTransition, error] {
+	return tracker.transitions(ctx, newOrderWatch(tracker, order))
+}
+
+func (tracker *OrderTracker) transitions(ctx context.Context, watch *orderWatch) iter.Seq2[*OrderTransition, error] {
+	return func(yield func(*OrderTransition, error) bool) {
+
+		for {
+			order, fills, done, err := watch.poll(ctx)
+			if err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			if order != nil && (done || watch.last == nil || order.Status != watch.last.Status) {
+				transition := &OrderTransition{
+					From: watch.status(), To: order.Status, Order: order, At: time.Now(), Fills: fills,
+				}
+				if !yield(transition, nil) {
+					return
+				}
+			}
+
+			if order != nil {
+				watch.last = order
+			}
+
+			if done {
+				return
+			}
+
+			err = sleepContext(ctx, watch.delay(order))
+			if err != nil {
+				yield(nil, err)
+
+				return
+			}
+		}
+	}
+}
+
+// WaitForTerminal waits for the order to be filled, cancelled, rejected or replaced,
+// and returns its final state.
+func (tracker *OrderTracker) WaitForTerminal(ctx context.Context, id int64) (*TrackedOrder, error) {
+	return tracker.waitForTerminal(tracker.Transitions(ctx, id))
+}
+
+// WaitForTerminalOf is WaitForTerminal of a known order, see TransitionsOf.
+func (tracker *OrderTracker) WaitForTerminalOf(ctx context.Context, order *models.Order) (*TrackedOrder, error) {
+	return tracker.waitForTerminal(tracker.TransitionsOf(ctx, order))
+}
+
+func (tracker *OrderTracker) waitForTerminal(transitions iter.Seq2[*OrderTransition, error]) (*TrackedOrder, error) {
+	var last *OrderTransition
+
+	for transition, err := range transitions {
+		if err != nil {
+			return nil, err
+		}
+
+		last = transition
+	}
+
+	return &TrackedOrder{Order: last.Order, Fills: last.Fills}, nil
+}
+
+// search looks the order up once in the historical orders, returning its final state, nil while it is
+// missing, and the delay to wait before searching again within the rate-limit.
+func (tracker *OrderTracker) search(ctx context.Context, order *models.Order) (*TrackedOrder, time.Duration, error) {
+	watch := newOrderWatch(tracker, order)
+
+	final, fills, err := watch.history(ctx)
+	if err != nil || final == nil {
+		return nil, watch.delay(nil), err
+	}
+
+	return &TrackedOrder{Order: final, Fills: fills}, 0, nil
+}
+
+// WaitForFill waits for the order to be filled, and returns its fills.
+// An order done without being filled is an error, returned with its partial fills, if any.
+func (tracker *OrderTracker) WaitForFill(ctx context.Context, id int64) ([]*models.OrderFill, error) {
+	return filledOrder(tracker.WaitForTerminal(ctx, id))
+}
+
+// WaitForFillOf is WaitForFill of a known order, see TransitionsOf.
+func (tracker *OrderTracker) WaitForFillOf(ctx context.Context, order *models.Order) ([]*models.OrderFill, error) {
+	return filledOrder(tracker.WaitForTerminalOf(ctx, order))
+}
+
+// filledOrder returns the fills of a filled order, or an error with the partial fills.
+func filledOrder(final *TrackedOrder, err error) ([]*models.OrderFill, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if final.Order.Status != models.OrderStatusFilled {
+		return final.Fills, fmt.Errorf("%w: order %d is %s", errOrderNotFilled, final.Order.ID, final.Order.Status)
+	}
+
+	return final.Fills, nil
+}
+
+// orderWatch is the state of a tracked order.
+type orderWatch struct {
+	tracker *OrderTracker
+	id      int64
+	// last seen state, nil until the order is found.
+	last *models.Order
+	// searches of the historical orders so far.
+	searches int
+	// pages read by the last search.
+	pages int
+}
+
+func newOrderWatch(tracker *OrderTracker, order *models.Order) *orderWatch {
+	id := int64(order.ID) //nolint:gosec // ids fit in int64
+
+	return &orderWatch{tracker: tracker, id: id, last: order, searches: 0, pages: 0}
+}
+
+func (watch *orderWatch) status() models.OrderStatus {
+	if watch.last == nil {
+		return ""
+	}
+
+	return watch.last.Status
+}
+
+// delay before the next poll, longer while searching the historical orders, for each page read.
+func (watch *orderWatch) delay(order *models.Order) time.Duration {
+	if order == nil {
+		return watch.tracker.HistoryInterval * time.Duration(max(watch.pages, 1))
+	}
+
+	return watch.tracker.PollInterval
+}
+
+// poll returns the order while it is pending, or its final state and fills once in the historical orders.
+// The order is nil when it left the pending orders without showing in the historical ones yet.
+func (watch *orderWatch) poll(ctx context.Context) (*models.Order, []*models.OrderFill, bool, error) {
+	order, err := watch.tracker.pending.GetPendingOrderByIDWithContext(ctx, watch.id)
+	if err == nil && !order.Status.IsTerminal() {
+		return order, nil, false, nil
+	}
+
+	if err != nil && !IsNotFound(err) {
+		return nil, nil, false, err
+	}
+
+	final, fills, err := watch.history(ctx)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	if final == nil {
+		watch.searches++
+		if watch.searches >= watch.tracker.HistorySearches {
+			return nil, nil, false, fmt.Errorf("%w: order %d, after %d searches", errOrderNotFound, watch.id,
+				watch.searches)
+		}
+
+		return nil, nil, false, nil
+	}
+
+	return final, fills, true, nil
+}
+
+// history searches the recent historical orders for the order and its fills. Once the order is known,
+// only the orders of its ticker are searched, until a page older than the order.
+func (watch *orderWatch) history(ctx context.Context) (*models.Order, []*models.OrderFill, error) {
+	query := models.HistoryQuery{Cursor: 0, Ticker: "", Limit: 0}
+	if watch.last != nil {
+		query.Ticker = watch.last.Ticker
+	}
+
+	var (
+		order *models.Order
+		fills []*models.OrderFill
+	)
+
+	watch.pages = 0
+
+	pages := watch.tracker.history.GetHistoricalOrdersPages(ctx, query)
+	for range watch.tracker.HistoryPages {
+		if pages.Done() {
+			break
+		}
+
+		watch.pages++
+
+		page, err := pages.Next()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		recent := watch.last == nil
+		for _, fill := range page {
+			if watch.last != nil && !fill.CreatedAt.Before(watch.last.CreatedAt.Add(-reconcileClockSkew)) {
+				recent = true
+			}
+
+			if int64(fill.ID) != watch.id { //nolint:gosec // ids fit in int64
+				continue
+			}
+
+			if order == nil {
+				order = &fill.Order
+			}
+
+			if !fill.Fill.Quantity.IsZero() {
+				fills = append(fills, fill)
+			}
+		}
+
+		if !recent {
+			break
+		}
+	}
+
+	slices.Reverse(fills)
+
+	return order, fills, nil
+}
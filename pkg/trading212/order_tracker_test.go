@@ -0,0 +1,243 @@
+package trading212
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+// newTrackerMockAPI serves the pending order then the history responses in sequence, repeating the last one.
+// An empty pending response is a 404, as for an order no longer pending. It returns the history queries.
+func newTrackerMockAPI(t *testing.T, pending []string, history []string) (*OrderTracker, func() []string) {
+	t.Helper()
+
+	var (
+		mutex   sync.Mutex
+		queries []string
+	)
+
+	next := func(responses *[]string) string {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		response := (*responses)[0]
+		if len(*responses) > 1 {
+			*responses = (*responses)[1:]
+		}
+
+		return response
+	}
+
+	mockAPI := newRoutesMockAPI(t, map[APIEndpoint]http.HandlerFunc{
+		http.MethodGet + " " + GetPendingOrderByID + "/42": func(w http.ResponseWriter, _ *http.Request) {
+			response := next(&pending)
+			if response == "" {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			_, _ = fmt.Fprint(w, response)
+		},
+		http.MethodGet + " " + GetHistoricalOrders: func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			queries = append(queries, r.URL.RawQuery)
+			mutex.Unlock()
+
+			_, _ = fmt.Fprint(w, next(&history))
+		},
+	})
+
+	tracker := NewOrderTracker(mockAPI)
+	tracker.PollInterval = time.Millisecond
+	tracker.HistoryInterval = time.Millisecond
+
+	return tracker, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return slices.Clone(queries)
+	}
+}
+
+func Test_OrderTracker(t *testing.T) {
+	t.Parallel()
+
+	const (
+		createdAt = `"createdAt": "2024-01-02T15:00:00Z"`
+		order     = `{"id": 42, "ticker": "AAPL_US_EQ", "quantity": 2, ` + createdAt + `, "status": %q}`
+		fill      = `{"order": {"id": 42, "ticker": "AAPL_US_EQ", "status": %q, ` + createdAt + `}, ` +
+			`"fill": {"id": %d, "quantity": %d, "price": 150}}`
+		other = `{"order": {"id": 41, "ticker": "AAPL_US_EQ", "status": "FILLED", ` +
+			`"createdAt": "2024-01-01T15:00:00Z"}, "fill": {"id": 1, "quantity": 1}}`
+	)
+
+	filled := fmt.Sprintf(`{"items": [%s, %s, %s], "nextPagePath": null}`,
+		fmt.Sprintf(fill, "FILLED", 3, 1), fmt.Sprintf(fill, "FILLED", 2, 1), other)
+	cancelled := fmt.Sprintf(`{"items": [%s], "nextPagePath": null}`, fmt.Sprintf(fill, "CANCELLED", 0, 0))
+
+	tests := []struct {
+		name            string
+		pending         []string
+		history         []string
+		wantTransitions string
+		wantFills       string
+		wantErr         error
+	}{
+		{
+			name:            "filled in two parts",
+			pending:         []string{fmt.Sprintf(order, "NEW"), fmt.Sprintf(order, "PARTIALLY_FILLED"), ""},
+			history:         []string{filled},
+			wantTransitions: "[ NEW] [NEW PARTIALLY_FILLED] [PARTIALLY_FILLED FILLED]",
+			wantFills:       "[2 3]",
+			wantErr:         nil,
+		},
+		{
+			name:            "cancelled",
+			pending:         []string{fmt.Sprintf(order, "NEW"), fmt.Sprintf(order, "CANCELLING"), ""},
+			history:         []string{cancelled},
+			wantTransitions: "[ NEW] [NEW CANCELLING] [CANCELLING CANCELLED]",
+			wantFills:       "[]",
+			wantErr:         errOrderNotFilled,
+		},
+		{
+			name:            "not in the history yet",
+			pending:         []string{""},
+			history:         []string{`{"items": [], "nextPagePath": null}`, filled},
+			wantTransitions: "[ FILLED]",
+			wantFills:       "[2 3]",
+			wantErr:         nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tracker, _ := newTrackerMockAPI(t, tt.pending, tt.history)
+
+			transitions := ""
+
+			for transition, err := range tracker.Transitions(context.Background(), 42) {
+				if err != nil {
+					t.Fatalf("Transitions() error = %v", err)
+				}
+
+				transitions += fmt.Sprintf(" [%s %s]", transition.From, transition.To)
+			}
+
+			if transitions[1:] != tt.wantTransitions {
+				t.Errorf("Transitions() = %s, want %s", transitions[1:], tt.wantTransitions)
+			}
+
+			tracker, _ = newTrackerMockAPI(t, tt.pending, tt.history)
+
+			fills, err := tracker.WaitForFill(context.Background(), 42)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("WaitForFill() error = %v, want %v", err, tt.wantErr)
+			}
+
+			ids := []int{}
+			for _, fill := range fills {
+				ids = append(ids, fill.Fill.ID)
+			}
+
+			if fmt.Sprint(ids) != tt.wantFills {
+				t.Errorf("WaitForFill() fills = %v, want %s", ids, tt.wantFills)
+			}
+		})
+	}
+}
+
+func Test_OrderTracker_Context(t *testing.T) {
+	t.Parallel()
+
+	tracker, _ := newTrackerMockAPI(t, []string{`{"id": 42, "ticker": "AAPL_US_EQ", "status": "NEW"}`}, []string{""})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	final, err := tracker.WaitForTerminal(ctx, 42)
+	if final != nil || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForTerminal() = %v, %v, want %v", final, err, context.DeadlineExceeded)
+	}
+}
+
+func Test_OrderTracker_Of(t *testing.T) {
+	t.Parallel()
+
+	const older = `{"items": [{"order": {"id": 41, "ticker": "AAPL_US_EQ", "status": "FILLED", ` +
+		`"createdAt": "2024-01-01T15:00:00Z"}, "fill": {"id": 1, "quantity": 1}}], ` +
+		`"nextPagePath": "/api/v0/equity/history/orders?cursor=41&ticker=AAPL_US_EQ"}`
+
+	filled := `{"items": [{"order": {"id": 42, "ticker": "AAPL_US_EQ", "status": "FILLED", ` +
+		`"createdAt": "2024-01-02T15:00:00Z"}, "fill": {"id": 2, "quantity": 2}}], "nextPagePath": null}`
+
+	tracker, queries := newTrackerMockAPI(t, []string{""}, []string{older, filled})
+
+	placed := &models.Order{}
+	placed.ID, placed.Ticker, placed.Status = 42, "AAPL_US_EQ", models.OrderStatusNew
+	placed.CreatedAt = time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	transitions := ""
+
+	for transition, err := range tracker.TransitionsOf(context.Background(), placed) {
+		if err != nil {
+			t.Fatalf("TransitionsOf() error = %v", err)
+		}
+
+		transitions += fmt.Sprintf("[%s %s]", transition.From, transition.To)
+	}
+
+	if transitions != "[NEW FILLED]" {
+		t.Errorf("TransitionsOf() = %s, want [NEW FILLED]", transitions)
+	}
+
+	// the search stops at the page older than the order, and searches again from the first page
+	if want := "[limit=50&ticker=AAPL_US_EQ limit=50&ticker=AAPL_US_EQ]"; fmt.Sprint(queries()) != want {
+		t.Errorf("TransitionsOf() history queries = %v, want %s", queries(), want)
+	}
+}
+
+func Test_OrderTracker_NotFound(t *testing.T) {
+	t.Parallel()
+
+	tracker, queries := newTrackerMockAPI(t, []string{""}, []string{`{"items": [], "nextPagePath": null}`})
+	tracker.HistorySearches = 3
+
+	final, err := tracker.WaitForTerminal(context.Background(), 42)
+	if final != nil || !errors.Is(err, errOrderNotFound) {
+		t.Errorf("WaitForTerminal() = %v, %v, want %v", final, err, errOrderNotFound)
+	}
+
+	if got := len(queries()); got != 3 {
+		t.Errorf("WaitForTerminal() searched the history %d times, want 3", got)
+	}
+}
+
+func Test_orderWatch_delay(t *testing.T) {
+	t.Parallel()
+
+	tracker := newOrderTracker(nil, nil)
+	watch := &orderWatch{tracker: tracker, id: 42, last: nil, searches: 1, pages: 0}
+
+	if got := watch.delay(nil); got != 10*time.Second {
+		t.Errorf("delay() = %s, want the history interval", got)
+	}
+
+	// each page read counts toward the rate-limit of the historical orders
+	watch.pages = 3
+	if got := watch.delay(nil); got != 30*time.Second {
+		t.Errorf("delay() = %s, want the history interval for each page read", got)
+	}
+
+	if got := watch.delay(&models.Order{}); got != time.Second {
+		t.Errorf("delay() = %s, want the poll interval while pending", got)
+	}
+}
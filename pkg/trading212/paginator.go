@@ -0,0 +1,172 @@
+package trading212
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var errPaginatorDone = errors.New("no more pages")
+
+// Cursor is an opaque token pointing to a page of a paginated endpoint.
+// It can be stored, e.g. as json or text, to resume an interrupted walk later with Paginator.Resume.
+// The empty Cursor points to the first page.
+type Cursor string
+
+// Paginator walks the pages of a paginated endpoint, one fresh request per page.
+// It is not safe for concurrent use.
+type Paginator[T any] struct {
+	ctx      context.Context //nolint:containedctx // bound to the walk, like a request
+	api      requestMaker
+	method   string
+	endpoint APIEndpoint
+	cursor   Cursor
+	done     bool
+	err      error
+}
+
+func newPaginator[T any](ctx context.Context, api requestMaker, method string, endpoint APIEndpoint) *Paginator[T] {
+	return &Paginator[T]{
+		ctx:      ctx,
+		api:      api,
+		method:   method,
+		endpoint: endpoint,
+		cursor:   "",
+		done:     false,
+		err:      nil,
+	}
+}
+
+// failedPaginator returns a Paginator whose walk fails with err.
+func failedPaginator[T any](err error) *Paginator[T] {
+	paginator := newPaginator[T](context.Background(), nil, http.MethodGet, "")
+	paginator.err = err
+
+	return paginator
+}
+
+// Cursor returns the token of the next page to fetch.
+func (p *Paginator[T]) Cursor() Cursor {
+	return p.cursor
+}
+
+// Done reports whether the last page was fetched.
+func (p *Paginator[T]) Done() bool {
+	return p.done
+}
+
+// Resume moves the Paginator to the page of the cursor, as returned by Cursor.
+func (p *Paginator[T]) Resume(cursor Cursor) {
+	p.cursor = cursor
+	p.done = false
+}
+
+// Next fetches the page of the current cursor, then moves the cursor to the following page.
+// The cursor is left unchanged on failure, so calling Next again retries the same page.
+func (p *Paginator[T]) Next() ([]*T, error) {
+	var data []*T
+
+	_, err := p.page(func(value *T) bool {
+		data = append(data, value)
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// All get iterator over the results of the remaining pages, decoded as they are iterated.
+// Any failure is yielded as the last element. Breaking out of the loop leaves the cursor on
+// the current page, which is then fetched again by the next walk.
+func (p *Paginator[T]) All() iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		for !p.done {
+			stopped, err := p.page(func(value *T) bool {
+				return yield(value, nil)
+			})
+
+			switch {
+			case stopped:
+				return
+			case err != nil:
+				yield(nil, err)
+
+				return
+			}
+		}
+	}
+}
+
+// page fetches and yields the page of the current cursor.
+// The cursor moves to the following page once the page is fully yielded.
+func (p *Paginator[T]) page(yield func(*T) bool) (bool, error) {
+	if p.err != nil {
+		return false, p.err
+	}
+
+	if p.done {
+		return false, errPaginatorDone
+	}
+
+	request, err := p.api.NewRequestWithContext(p.ctx, p.method, p.cursorEndpoint(), nil)
+	if err != nil {
+		return false, err
+	}
+
+	body, err := request.stream()
+	if err != nil {
+		return false, err
+	}
+
+	defer func() { _ = body.Close() }()
+
+	nextPagePath, stopped, err := decodeStream(body, p.decoding(), yield)
+	if err != nil || stopped {
+		return stopped, err
+	}
+
+	if nextPagePath == nil || *nextPagePath == "" {
+		p.done = true
+	} else {
+		p.cursor = Cursor(*nextPagePath)
+	}
+
+	return false, nil
+}
+
+// decoding of the responses, strict without a client.
+func (p *Paginator[T]) decoding() decoding {
+	if p.api == nil {
+		return decoding{mode: DecodingStrict, drifts: nil}
+	}
+
+	return p.api.responseDecoding()
+}
+
+// cursorEndpoint resolves the cursor to the endpoint of its page.
+// Trading212 gives the next page as a path with its query, a bare cursor value is also accepted.
+func (p *Paginator[T]) cursorEndpoint() APIEndpoint {
+	switch {
+	case p.cursor == "":
+		return p.endpoint
+	case strings.HasPrefix(string(p.cursor), "/"):
+		return APIEndpoint(p.cursor)
+	}
+
+	path, rawQuery, _ := strings.Cut(string(p.endpoint), "?")
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		query = url.Values{}
+	}
+
+	query.Set("cursor", string(p.cursor))
+
+	return APIEndpoint(path).withQuery(query)
+}
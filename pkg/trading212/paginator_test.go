@@ -0,0 +1,196 @@
+package trading212
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+// newPagesMockAPI serves the transactions in pages of two, failing once on the page of failCursor.
+func newPagesMockAPI(t *testing.T, total int, failCursor string) (*API, func() int) {
+	t.Helper()
+
+	var mutex sync.Mutex
+
+	calls := 0
+	failed := false
+	mockAPI := newRoutesMockAPI(t, map[APIEndpoint]http.HandlerFunc{
+		http.MethodGet + " " + GetTransactions: func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			calls++
+			cursor := r.URL.Query().Get("cursor")
+
+			if cursor == failCursor && !failed {
+				failed = true
+
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			start := 0
+			if cursor != "" {
+				_, _ = fmt.Sscan(cursor, &start)
+			}
+
+			items := make([]string, 0, 2)
+			for index := start; index < min(start+2, total); index++ {
+				items = append(items, fmt.Sprintf(
+					`{"amount": %d, "currency": "EUR", "dateTime": "2019-08-24T14:15:22Z", `+
+						`"reference": "%d", "type": "DEPOSIT"}`, index, index,
+				))
+			}
+
+			next := "null"
+			if start+2 < total {
+				next = fmt.Sprintf(`"%s?cursor=%d&limit=2"`, GetTransactions, start+2)
+			}
+
+			_, _ = fmt.Fprintf(w, `{"items": [%s], "nextPagePath": %s}`, strings.Join(items, ","), next)
+		},
+	})
+
+	return mockAPI, func() int {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return calls
+	}
+}
+
+func collectAmounts(t *testing.T, all iter.Seq2[*models.Transaction, error]) ([]int, error) {
+	t.Helper()
+
+	amounts := []int{}
+	for transaction, err := range all {
+		if err != nil {
+			return amounts, err
+		}
+
+		amounts = append(amounts, int(transaction.Amount.Float64()))
+	}
+
+	return amounts, nil
+}
+
+func Test_Paginator_Resume(t *testing.T) {
+	t.Parallel()
+
+	mockAPI, _ := newPagesMockAPI(t, 5, "none")
+	query := models.TransactionsQuery{Cursor: "", Time: time.Time{}, Limit: 2}
+
+	paginator := mockAPI.HistoricalEvents.GetTransactionsPages(context.Background(), query)
+
+	page, err := paginator.Next()
+	if err != nil || len(page) != 2 {
+		t.Fatalf("Next() = %d items, %v, want 2 items", len(page), err)
+	}
+
+	// interrupt the walk, then save the cursor
+	cursor, err := json.Marshal(paginator.Cursor())
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var restored Cursor
+
+	err = json.Unmarshal(cursor, &restored)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	resumed := mockAPI.HistoricalEvents.GetTransactionsPages(context.Background(), query)
+	resumed.Resume(restored)
+
+	amounts, err := collectAmounts(t, resumed.All())
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+
+	if fmt.Sprint(amounts) != "[2 3 4]" || !resumed.Done() {
+		t.Errorf("All() = %v, done %v, want [2 3 4], done", amounts, resumed.Done())
+	}
+
+	// a bare cursor value is also accepted
+	resumed.Resume("4")
+
+	amounts, err = collectAmounts(t, resumed.All())
+	if err != nil || fmt.Sprint(amounts) != "[4]" {
+		t.Errorf("All() = %v, %v, want [4]", amounts, err)
+	}
+}
+
+func Test_Paginator_Failure(t *testing.T) {
+	t.Parallel()
+
+	mockAPI, _ := newPagesMockAPI(t, 6, "2")
+	query := models.TransactionsQuery{Cursor: "", Time: time.Time{}, Limit: 2}
+	paginator := mockAPI.HistoricalEvents.GetTransactionsPages(context.Background(), query)
+
+	amounts, err := collectAmounts(t, paginator.All())
+	if err == nil || fmt.Sprint(amounts) != "[0 1]" {
+		t.Fatalf("All() = %v, %v, want [0 1] and an error", amounts, err)
+	}
+
+	// the failed page is fetched again
+	amounts, err = collectAmounts(t, paginator.All())
+	if err != nil || fmt.Sprint(amounts) != "[2 3 4 5]" {
+		t.Errorf("All() = %v, %v, want [2 3 4 5]", amounts, err)
+	}
+
+	_, err = paginator.Next()
+	if err == nil {
+		t.Errorf("Next() after the last page should fail")
+	}
+}
+
+func Test_Paginator_Break(t *testing.T) {
+	t.Parallel()
+
+	mockAPI, _ := newPagesMockAPI(t, 4, "none")
+	query := models.TransactionsQuery{Cursor: "", Time: time.Time{}, Limit: 2}
+	paginator := mockAPI.HistoricalEvents.GetTransactionsPages(context.Background(), query)
+
+	for range paginator.All() {
+		break
+	}
+
+	if paginator.Cursor() != "" {
+		t.Errorf("Cursor() = %q, want the first page", paginator.Cursor())
+	}
+
+	amounts, err := collectAmounts(t, paginator.All())
+	if err != nil || fmt.Sprint(amounts) != "[0 1 2 3]" {
+		t.Errorf("All() = %v, %v, want [0 1 2 3]", amounts, err)
+	}
+}
+
+func Test_Response_IterateTwice(t *testing.T) {
+	t.Parallel()
+
+	mockAPI, calls := newPagesMockAPI(t, 5, "none")
+
+	response := runOperation[models.Transaction](context.Background(), mockAPI, http.MethodGet, GetTransactions, nil)
+
+	for range 2 {
+		amounts, err := collectAmounts(t, response.All())
+		if err != nil || fmt.Sprint(amounts) != "[0 1 2 3 4]" {
+			t.Errorf("All() = %v, %v, want [0 1 2 3 4]", amounts, err)
+		}
+	}
+
+	// each walk fetches every page, the first one being streamed by the first walk only
+	if got := calls(); got != 6 {
+		t.Errorf("calls = %d, want 6", got)
+	}
+}
@@ -1,12 +1,14 @@
 package trading212
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"math"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -50,43 +52,193 @@ type APIRateLimits struct {
 	Used uint64
 }
 
-// RateLimiter type
+// documentedRateLimits per method and endpoint template, used until the server tells otherwise.
+// See: https://docs.trading212.com/api
+//
+//nolint:gochecknoglobals,mnd
+var documentedRateLimits = map[APIEndpoint]struct {
+	limit  uint64
+	period time.Duration
+}{
+	http.MethodGet + " " + GetAccountSummary:                 {limit: 1, period: 5 * time.Second},
+	http.MethodGet + " " + GetExchangesMetadata:              {limit: 1, period: 30 * time.Second},
+	http.MethodGet + " " + GetAllAvailableInstruments:        {limit: 1, period: 50 * time.Second},
+	http.MethodGet + " " + GetAllPendingOrders:               {limit: 1, period: 5 * time.Second},
+	http.MethodGet + " " + GetPendingOrderByID + "/{id}":     {limit: 1, period: time.Second},
+	http.MethodPost + " " + PlaceLimitOrder:                  {limit: 1, period: 2 * time.Second},
+	http.MethodPost + " " + PlaceMarketOrder:                 {limit: 50, period: time.Minute},
+	http.MethodPost + " " + PlaceStopOrder:                   {limit: 1, period: 2 * time.Second},
+	http.MethodPost + " " + PlaceStopLimitOrder:              {limit: 1, period: 2 * time.Second},
+	http.MethodDelete + " " + CancelOrder + "/{id}":          {limit: 50, period: time.Minute},
+	http.MethodGet + " " + GetAllPositions:                   {limit: 1, period: time.Second},
+	http.MethodGet + " " + GetDividends:                      {limit: 6, period: time.Minute},
+	http.MethodGet + " " + GetHistoricalOrders:               {limit: 6, period: time.Minute},
+	http.MethodGet + " " + GetTransactions:                   {limit: 6, period: time.Minute},
+	http.MethodGet + " " + ListReports:                       {limit: 1, period: time.Minute},
+	http.MethodPost + " " + RequestReport:                    {limit: 1, period: 30 * time.Second},
+	http.MethodGet + " " + GetAllPies:                        {limit: 1, period: 30 * time.Second},
+	http.MethodPost + " " + CreatePie:                        {limit: 1, period: 5 * time.Second},
+	http.MethodDelete + " " + DeletePie + "/{id}":            {limit: 1, period: 5 * time.Second},
+	http.MethodGet + " " + FetchPie + "/{id}":                {limit: 1, period: 5 * time.Second},
+	http.MethodPost + " " + UpdatePie + "/{id}":              {limit: 1, period: 5 * time.Second},
+	http.MethodPost + " " + DuplicatePie + "/{id}/duplicate": {limit: 1, period: 5 * time.Second},
+}
+
+// RateLimiter type.
+// It is safe for concurrent use, so a single RateLimiter can be shared between goroutines and clients.
+// Limits are tracked per method and endpoint template, see APIEndpoint.Template.
+//
+// Each endpoint starts from its documented limit, then follows the x-ratelimit-* headers.
+// Requests are spread evenly over the period, one every period / limit, instead of bursting
+// until none remain. Callers queue on their bucket and are released in order.
+// The reset time is corrected by the skew between the server Date header and the local clock.
 type RateLimiter struct {
-	limits map[string]APIRateLimits
-	sleep  func(time.Duration)
+	mutex   sync.Mutex
+	buckets map[string]*rateLimitBucket
+	skew    time.Duration
+	sleep   func(context.Context, time.Duration) error
+	now     func() time.Time
+	logger  *slog.Logger
+}
+
+// rateLimitBucket tracks the limits of an endpoint template.
+type rateLimitBucket struct {
+	limits APIRateLimits
+	// next is the earliest time the next request may be sent.
+	next time.Time
+}
+
+// interval between two requests to spread them evenly over the period.
+func (bucket *rateLimitBucket) interval() time.Duration {
+	if bucket.limits.Limit == 0 {
+		return 0
+	}
+
+	return bucket.limits.Period / time.Duration(bucket.limits.Limit) //nolint:gosec
 }
 
 // NewRateLimiter creates a RateLimiter
 func NewRateLimiter() *RateLimiter {
 	return &RateLimiter{
-		limits: make(map[string]APIRateLimits),
-		sleep:  time.Sleep,
+		mutex:   sync.Mutex{},
+		buckets: make(map[string]*rateLimitBucket),
+		skew:    0,
+		sleep:   sleepContext,
+		now:     time.Now,
+		logger:  slog.Default(),
+	}
+}
+
+func rateLimitKey(method string, path string) string {
+	return method + " " + string(APIEndpoint(path).Template())
+}
+
+// bucket returns the bucket for the key, seeded from the documented limits when new.
+// The mutex must be held.
+func (r *RateLimiter) bucket(key string) (*rateLimitBucket, bool) {
+	bucket, found := r.buckets[key]
+	if found {
+		return bucket, true
+	}
+
+	documented, found := documentedRateLimits[APIEndpoint(key)]
+	if !found {
+		return nil, false
+	}
+
+	bucket = &rateLimitBucket{
+		limits: APIRateLimits{
+			Limit:     documented.limit,
+			Period:    documented.period,
+			Remaining: documented.limit,
+			Reset:     time.Time{},
+			Used:      0,
+		},
+		next: time.Time{},
+	}
+	r.buckets[key] = bucket
+
+	return bucket, true
+}
+
+// Limits returns the last known limits for the method and path, if any.
+func (r *RateLimiter) Limits(method string, path string) (APIRateLimits, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	bucket, found := r.bucket(rateLimitKey(method, path))
+	if !found {
+		return APIRateLimits{}, false
+	}
+
+	return bucket.limits, true
+}
+
+// SetLimit overrides the limit of an endpoint, until the server tells otherwise.
+func (r *RateLimiter) SetLimit(method string, endpoint APIEndpoint, limit uint64, period time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := rateLimitKey(method, string(endpoint))
+
+	bucket, found := r.bucket(key)
+	if !found {
+		bucket = &rateLimitBucket{limits: APIRateLimits{}, next: time.Time{}}
+		r.buckets[key] = bucket
 	}
+
+	bucket.limits.Limit = limit
+	bucket.limits.Period = period
+	bucket.limits.Remaining = limit
 }
 
 // ApplyRateLimit will sleep if a rate limit is in place.
-func (r *RateLimiter) ApplyRateLimit(path string) {
-	limits, found := r.limits[path]
+// The wait is interrupted, and the context cause returned, when ctx is done.
+func (r *RateLimiter) ApplyRateLimit(ctx context.Context, method string, path string) error {
+	wait := r.reserve(rateLimitKey(method, path))
+	if wait <= 0 {
+		return nil
+	}
+
+	return r.sleep(ctx, wait)
+}
+
+// reserve the next slot in the bucket, and returns how long to wait for it.
+func (r *RateLimiter) reserve(key string) time.Duration {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	bucket, found := r.bucket(key)
 	if !found {
-		return
+		return 0
 	}
 
-	slog.Debug("Limit rate", "limits", limits)
+	r.logger.Debug("Limit rate", "endpoint", key, "limits", bucket.limits)
 
-	if limits.Remaining > 0 {
-		return
+	now := r.now()
+	limits := &bucket.limits
+
+	slot := now
+	if bucket.next.After(slot) {
+		slot = bucket.next
 	}
 
-	now := time.Now()
-	if now.After(limits.Reset) {
-		return
+	if limits.Remaining == 0 && limits.Reset.After(slot) {
+		slot = limits.Reset
+	}
+
+	if limits.Remaining > 0 {
+		limits.Remaining--
+		limits.Used++
 	}
 
-	r.sleep(time.Until(limits.Reset))
+	bucket.next = slot.Add(bucket.interval())
+
+	return slot.Sub(now)
 }
 
 // ParseRateLimits parses the http response rate limit headers.
-func (r *RateLimiter) ParseRateLimits(path string, response *http.Response) error {
+func (r *RateLimiter) ParseRateLimits(method string, path string, response *http.Response) error {
 	if response == nil || response.Header == nil || response.Request == nil || response.Request.URL == nil {
 		return headerNotFoundError("response is nil")
 	}
@@ -120,14 +272,46 @@ func (r *RateLimiter) ParseRateLimits(path string, response *http.Response) erro
 		return errHeaderConversion
 	}
 
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.parseClockSkew(response)
+
 	rateLimits := &APIRateLimits{
 		Limit:     headers[RateLimitHeaderLimit],
 		Period:    time.Duration(headers[RateLimitHeaderPeriod]) * time.Second, //nolint:gosec
 		Remaining: headers[RateLimitHeaderRemaining],
-		Reset:     time.Unix(int64(headers[RateLimitHeaderReset]), 0), //nolint:gosec
-		Used:      headers[RateLimitHeaderUsed],
+		// converted to the local clock
+		Reset: time.Unix(int64(headers[RateLimitHeaderReset]), 0).Add(-r.skew), //nolint:gosec
+		Used:  headers[RateLimitHeaderUsed],
+	}
+
+	key := rateLimitKey(method, path)
+
+	bucket, found := r.bucket(key)
+	if !found {
+		bucket = &rateLimitBucket{limits: APIRateLimits{}, next: time.Time{}}
+		r.buckets[key] = bucket
 	}
 
-	r.limits[path] = *rateLimits
+	bucket.limits = *rateLimits
+
 	return nil
 }
+
+// parseClockSkew measures the skew between the server Date header and the local clock.
+// The Date header has a one-second resolution, so smaller skews are ignored.
+// The mutex must be held.
+func (r *RateLimiter) parseClockSkew(response *http.Response) {
+	date, err := http.ParseTime(response.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+
+	skew := date.Sub(r.now())
+	if skew.Abs() <= time.Second {
+		skew = 0
+	}
+
+	r.skew = skew
+}
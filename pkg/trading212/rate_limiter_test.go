@@ -1,10 +1,14 @@
 package trading212
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"reflect"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 )
@@ -82,14 +86,22 @@ func TestApplyRateLimit(t *testing.T) {
 				t.Parallel()
 
 				rateLimiter := NewRateLimiter()
-				rateLimiter.limits = tt.args.rateLimits
+				for path, limits := range tt.args.rateLimits {
+					rateLimiter.buckets[rateLimitKey(http.MethodGet, path)] = &rateLimitBucket{limits: limits}
+				}
 
 				called := false
-				rateLimiter.sleep = func(_ time.Duration) {
+				rateLimiter.sleep = func(_ context.Context, _ time.Duration) error {
 					called = true
+
+					return nil
+				}
+
+				err := rateLimiter.ApplyRateLimit(context.Background(), http.MethodGet, tt.args.path)
+				if err != nil {
+					t.Errorf("ApplyRateLimit() unexpected error = %v", err)
 				}
 
-				rateLimiter.ApplyRateLimit(tt.args.path)
 				if called != tt.sleep {
 					t.Errorf("ApplyRateLimit() called = %v; want %v", called, tt.sleep)
 				}
@@ -98,6 +110,32 @@ func TestApplyRateLimit(t *testing.T) {
 	}
 }
 
+func TestApplyRateLimitContext(t *testing.T) {
+	t.Parallel()
+
+	rateLimiter := NewRateLimiter()
+	rateLimiter.buckets[rateLimitKey(http.MethodGet, "new/path")] = &rateLimitBucket{
+		limits: APIRateLimits{
+			Remaining: 0,
+			Reset:     time.Now().Add(5 * time.Minute),
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+
+	err := rateLimiter.ApplyRateLimit(ctx, http.MethodGet, "new/path")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ApplyRateLimit() error = %v, want %v", err, context.Canceled)
+	}
+
+	if time.Since(start) > time.Second {
+		t.Errorf("ApplyRateLimit() did not return on cancelled context")
+	}
+}
+
 func TestParseRateLimits(t *testing.T) {
 	t.Parallel()
 
@@ -221,13 +259,13 @@ func TestParseRateLimits(t *testing.T) {
 				}
 
 				rateLimiter := NewRateLimiter()
-				err := rateLimiter.ParseRateLimits("foo", tt.args.response)
+				err := rateLimiter.ParseRateLimits(http.MethodGet, "foo", tt.args.response)
 				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
 					t.Errorf("ParseRateLimits() error = %v, wantErr %v", err, tt.wantErr)
 					return
 				}
 
-				limits, ok := rateLimiter.limits["foo"]
+				limits, ok := rateLimiter.Limits(http.MethodGet, "foo")
 				if (!ok && tt.want != nil) || (ok && !reflect.DeepEqual(limits, *tt.want)) {
 					t.Errorf("ParseRateLimits() error limits not equal %v, want %v", limits, tt.want)
 				}
@@ -235,3 +273,193 @@ func TestParseRateLimits(t *testing.T) {
 		)
 	}
 }
+
+func TestRateLimiterTemplate(t *testing.T) {
+	t.Parallel()
+
+	rateLimiter := NewRateLimiter()
+	response := &http.Response{Header: http.Header{}, Request: &http.Request{URL: &url.URL{}}}
+	response.Header.Set(RateLimitHeaderLimit, "1")
+	response.Header.Set(RateLimitHeaderPeriod, "1")
+	response.Header.Set(RateLimitHeaderRemaining, "0")
+	response.Header.Set(RateLimitHeaderReset, "1765055699")
+	response.Header.Set(RateLimitHeaderUsed, "1")
+
+	err := rateLimiter.ParseRateLimits(http.MethodGet, string(GetPendingOrderByID+"/123"), response)
+	if err != nil {
+		t.Fatalf("ParseRateLimits() error = %v", err)
+	}
+
+	if limits, _ := rateLimiter.Limits(http.MethodGet, string(GetPendingOrderByID+"/124")); limits.Reset.IsZero() {
+		t.Errorf("Limits() /orders/123 and /orders/124 should share the same bucket")
+	}
+
+	if limits, _ := rateLimiter.Limits(http.MethodDelete, string(CancelOrder+"/124")); !limits.Reset.IsZero() {
+		t.Errorf("Limits() methods should not share the same bucket")
+	}
+
+	if limits, _ := rateLimiter.Limits(http.MethodGet, string(GetAllPendingOrders)); !limits.Reset.IsZero() {
+		t.Errorf("Limits() /orders and /orders/{id} should not share the same bucket")
+	}
+}
+
+func TestRateLimiterFairQueue(t *testing.T) {
+	t.Parallel()
+
+	const callers = 8
+
+	now := time.Unix(1_700_000_000, 0)
+
+	rateLimiter := NewRateLimiter()
+	rateLimiter.now = func() time.Time { return now }
+	rateLimiter.buckets[rateLimitKey(http.MethodGet, "path")] = &rateLimitBucket{
+		limits: APIRateLimits{
+			Limit:     2,
+			Period:    2 * time.Second,
+			Remaining: 0,
+			Reset:     now.Add(time.Second),
+		},
+	}
+
+	var (
+		mutex sync.Mutex
+		waits []time.Duration
+		group sync.WaitGroup
+	)
+
+	rateLimiter.sleep = func(_ context.Context, wait time.Duration) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		waits = append(waits, wait)
+
+		return nil
+	}
+
+	for range callers {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			err := rateLimiter.ApplyRateLimit(context.Background(), http.MethodGet, "path")
+			if err != nil {
+				t.Errorf("ApplyRateLimit() error = %v", err)
+			}
+		}()
+	}
+
+	group.Wait()
+	slices.Sort(waits)
+
+	if len(waits) != callers {
+		t.Fatalf("ApplyRateLimit() expected %d waits, got %v", callers, waits)
+	}
+
+	for index, wait := range waits {
+		// first slot at reset, then spread by period / limit
+		want := time.Second + time.Duration(index)*time.Second
+		if wait != want {
+			t.Errorf("ApplyRateLimit() caller %d waits %v, want %v", index, wait, want)
+		}
+	}
+}
+
+func TestRateLimiterConcurrency(t *testing.T) {
+	t.Parallel()
+
+	rateLimiter := NewRateLimiter()
+	rateLimiter.sleep = func(_ context.Context, _ time.Duration) error { return nil }
+
+	response := &http.Response{Header: http.Header{}, Request: &http.Request{URL: &url.URL{}}}
+	response.Header.Set(RateLimitHeaderLimit, "5")
+	response.Header.Set(RateLimitHeaderPeriod, "1")
+	response.Header.Set(RateLimitHeaderRemaining, "1")
+	response.Header.Set(RateLimitHeaderReset, "1765055699")
+	response.Header.Set(RateLimitHeaderUsed, "4")
+
+	var group sync.WaitGroup
+
+	for index := range 50 {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			path := fmt.Sprintf("%s/%d", GetPendingOrderByID, index%10)
+			if index%2 == 0 {
+				_ = rateLimiter.ParseRateLimits(http.MethodGet, path, response)
+			}
+
+			_ = rateLimiter.ApplyRateLimit(context.Background(), http.MethodGet, path)
+			_, _ = rateLimiter.Limits(http.MethodGet, path)
+		}()
+	}
+
+	group.Wait()
+}
+
+func TestRateLimiterDocumentedLimits(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+
+	rateLimiter := NewRateLimiter()
+	rateLimiter.now = func() time.Time { return now }
+
+	limits, ok := rateLimiter.Limits(http.MethodPost, string(PlaceLimitOrder))
+	if !ok || limits.Limit != 1 || limits.Period != 2*time.Second {
+		t.Fatalf("Limits() should be seeded with the documented limits, got %+v", limits)
+	}
+
+	if _, ok := rateLimiter.Limits(http.MethodGet, "unknown/path"); ok {
+		t.Errorf("Limits() should not be seeded for unknown endpoints")
+	}
+
+	// 50 requests per minute are spread one every 1.2s
+	for index := range 3 {
+		want := time.Duration(index) * 1200 * time.Millisecond
+		if wait := rateLimiter.reserve(rateLimitKey(http.MethodPost, string(PlaceMarketOrder))); wait != want {
+			t.Errorf("reserve() request %d waits %v, want %v", index, wait, want)
+		}
+	}
+
+	rateLimiter.SetLimit(http.MethodGet, GetAllPositions, 10, time.Second)
+
+	limits, _ = rateLimiter.Limits(http.MethodGet, string(GetAllPositions))
+	if limits.Limit != 10 || limits.Period != time.Second {
+		t.Errorf("SetLimit() should override the documented limits, got %+v", limits)
+	}
+}
+
+func TestRateLimiterClockSkew(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	serverNow := now.Add(time.Minute)
+
+	rateLimiter := NewRateLimiter()
+	rateLimiter.now = func() time.Time { return now }
+
+	response := &http.Response{Header: http.Header{}, Request: &http.Request{URL: &url.URL{}}}
+	response.Header.Set("Date", serverNow.UTC().Format(http.TimeFormat))
+	response.Header.Set(RateLimitHeaderLimit, "1")
+	response.Header.Set(RateLimitHeaderPeriod, "10")
+	response.Header.Set(RateLimitHeaderRemaining, "0")
+	response.Header.Set(RateLimitHeaderReset, fmt.Sprint(serverNow.Add(5*time.Second).Unix()))
+	response.Header.Set(RateLimitHeaderUsed, "1")
+
+	err := rateLimiter.ParseRateLimits(http.MethodGet, "path", response)
+	if err != nil {
+		t.Fatalf("ParseRateLimits() error = %v", err)
+	}
+
+	limits, _ := rateLimiter.Limits(http.MethodGet, "path")
+	if !limits.Reset.Equal(now.Add(5 * time.Second)) {
+		t.Errorf("ParseRateLimits() reset should be in local time, got %v want %v", limits.Reset, now.Add(5*time.Second))
+	}
+
+	if wait := rateLimiter.reserve(rateLimitKey(http.MethodGet, "path")); wait != 5*time.Second {
+		t.Errorf("reserve() should wait for the reset, got %v", wait)
+	}
+}
@@ -4,15 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
-	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
-const defaultMaxRetries = 10
-
 var (
 	errNewHTTP    = errors.New("fail to create http request")
 	errAPIRequest = errors.New("error executing api request")
@@ -22,6 +21,8 @@ var (
 	errHTTP403    = errors.New("error api return http 403; Scope missing for API key")
 	errHTTP408    = errors.New("error api return http 408; Timed-out")
 	errHTTP429    = errors.New("error api return http 429; Rate-Limited")
+
+	errOutcomeUnknown = errors.New("request may have been processed, outcome is unknown")
 )
 
 type knownErrorCode int
@@ -49,12 +50,49 @@ func httpError(code int, status string) error {
 		err = errNon200
 	}
 
-	return fmt.Errorf("%w (status: %s)", err, status)
+	return &APIError{
+		StatusCode: code,
+		Status:     status,
+		Method:     "",
+		Endpoint:   "",
+		Code:       "",
+		Message:    "",
+		Body:       nil,
+		RateLimits: nil,
+		err:        err,
+	}
+}
+
+// newAPIError builds the APIError of a non-2xx response.
+func (request *Request) newAPIError(response *http.Response, path string) error {
+	err := httpError(response.StatusCode, response.Status)
+
+	apiError, _ := err.(*APIError) //nolint:errorlint // built above
+	apiError.Method = request.httpRequest.Method
+	apiError.Endpoint = APIEndpoint(path).Template()
+
+	body, readErr := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+	if readErr == nil {
+		apiError.parseBody(body)
+	}
+
+	limits, found := request.api.rateLimits.Limits(apiError.Method, path)
+	if found {
+		apiError.RateLimits = &limits
+	}
+
+	return apiError
+}
+
+// ambiguousStatus reports whether the http status leaves unknown if the request was processed.
+func ambiguousStatus(code int) bool {
+	return code == int(timeout) || code >= http.StatusInternalServerError
 }
 
 // IRequest Request interface.
 type IRequest interface {
 	Do() (*json.RawMessage, error)
+	stream() (io.ReadCloser, error)
 	http() *http.Request
 }
 
@@ -67,10 +105,12 @@ type Request struct {
 	httpRequest *http.Request
 	retries     int
 	maxRetries  int
+	idempotent  bool
 }
 
 type requestMaker interface {
-	NewRequest(method string, path APIEndpoint, body io.Reader) (IRequest, error)
+	NewRequestWithContext(ctx context.Context, method string, path APIEndpoint, body io.Reader) (IRequest, error)
+	responseDecoding() decoding
 }
 
 // NewRequest build a Request for the API.
@@ -78,9 +118,28 @@ type requestMaker interface {
 //
 //nolint:ireturn
 func (api *API) NewRequest(method string, path APIEndpoint, body io.Reader) (IRequest, error) {
-	endpoint := api.domain.JoinPath(string(path)).String()
+	return api.NewRequestWithContext(context.Background(), method, path, body)
+}
+
+// NewRequestWithContext build a Request for the API bound to the given context.
+// Cancelling the context aborts the request, its rate-limit waits and retries,
+// as well as the fetching of any following page.
+// Prefer to use the available methods instead.
+//
+//nolint:ireturn
+func (api *API) NewRequestWithContext(
+	ctx context.Context, method string, path APIEndpoint, body io.Reader,
+) (IRequest, error) {
+	// the path may carry the query parameters
+	rawPath, rawQuery, _ := strings.Cut(string(path), "?")
+	path = APIEndpoint(rawPath)
+	endpoint := api.domain.JoinPath(rawPath).String()
+
+	body, err := replayableBody(body)
+	if err != nil {
+		return nil, errors.Join(errNewHTTP, err)
+	}
 
-	ctx := context.Background()
 	ctx, cancel := context.WithCancelCause(ctx)
 
 	request, err := http.NewRequestWithContext(ctx, method, endpoint, body)
@@ -94,9 +153,22 @@ func (api *API) NewRequest(method string, path APIEndpoint, body io.Reader) (IRe
 	request.SetBasicAuth(api.apiKey, string(api.apiSecret))
 	// api accepts json
 	request.Header.Set("Content-Type", "application/json")
-	// extend default pagination from 20 to 50 when available
-	query := request.URL.Query()
-	query.Set("limit", "50")
+	if api.userAgent != "" {
+		request.Header.Set("User-Agent", api.userAgent)
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		cancel(err)
+
+		return nil, errors.Join(errNewHTTP, err)
+	}
+
+	// extend default pagination from 20 to the page size, unless set by the caller
+	if isPaginated(method, path) && !query.Has("limit") {
+		query.Set("limit", strconv.Itoa(api.pageSize))
+	}
+
 	request.URL.RawQuery = query.Encode()
 
 	return &Request{
@@ -105,64 +177,208 @@ func (api *API) NewRequest(method string, path APIEndpoint, body io.Reader) (IRe
 		api:         api,
 		httpRequest: request,
 		retries:     0,
-		maxRetries:  defaultMaxRetries,
+		maxRetries:  api.maxRetries,
+		idempotent:  isIdempotent(method, path),
 	}, nil
 }
 
-// Do executes the current request.
+// Do executes the current request, retrying failed attempts according to the client RetryPolicy.
+// Non-idempotent requests, like order placements, are only retried when rate-limited since the
+// request was then rejected before being processed. Their other failures that may have reached
+// the server wrap errOutcomeUnknown.
 func (request *Request) Do() (*json.RawMessage, error) {
 	defer request.cancel(nil)
 
-	rateLimitPath := request.httpRequest.URL.EscapedPath()
-	request.api.rateLimits.ApplyRateLimit(rateLimitPath)
-
-	//nolint:bodyclose // body is closed in lambda
-	response, err := request.api.client.Do(request.httpRequest)
+	body, err := request.stream()
 	if err != nil {
-		err := errors.Join(errAPIRequest, err)
+		return nil, err
+	}
+
+	defer func() { _ = body.Close() }()
 
+	data, err := io.ReadAll(body)
+	if err != nil {
 		return nil, err
 	}
 
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
+	request.api.logger.Debug("Response body", "body", data)
+
+	return (*json.RawMessage)(&data), nil
+}
+
+// stream executes the current request like Do, returning the body of the response unread.
+// Closing the body releases the request.
+func (request *Request) stream() (io.ReadCloser, error) {
+	var waited time.Duration
+
+	request.retries = 0
+
+	for {
+		body, failure, err := request.attempt()
+		if failure == nil || request.Ctx.Err() != nil {
+			if err != nil {
+				request.cancel(err)
+
+				return nil, err
+			}
+
+			return body, nil
+		}
+
+		request.retries++
+		failure.Attempt = request.retries
+		failure.Waited = waited
+
+		if !request.idempotent && failure.StatusCode() != int(rateLimited) {
+			request.cancel(err)
+
+			return nil, err
+		}
+
+		delay, retry := request.api.retryPolicy.Retry(*failure)
+		if !retry || request.retries > request.maxRetries {
+			request.cancel(err)
+
+			return nil, err
+		}
+
+		request.api.logger.Info(
+			"Retrying request",
+			"method", failure.Method,
+			"path", failure.Path,
+			"attempt", failure.Attempt,
+			"status", failure.StatusCode(),
+			"delay", delay,
+			"error", failure.Err,
+		)
+
+		if request.api.retryHook != nil {
+			request.api.retryHook(*failure, delay)
+		}
+
+		err = sleepContext(request.Ctx, delay)
 		if err != nil {
-			slog.Warn("error closing api response body")
+			err := errors.Join(errAPIRequest, err)
+			request.cancel(err)
+
+			return nil, err
 		}
-	}(response.Body)
 
-	slog.Debug("Request status", "status", response.Status)
+		waited += delay
+	}
+}
+
+// attempt executes the request once.
+// A non-nil RetryAttempt is returned when the failure may be retried.
+// On success, the body of the response is returned unread.
+func (request *Request) attempt() (io.ReadCloser, *RetryAttempt, error) {
+	rateLimitPath := request.httpRequest.URL.EscapedPath()
 
-	err = request.api.rateLimits.ParseRateLimits(rateLimitPath, response)
+	err := request.api.rateLimits.ApplyRateLimit(request.Ctx, request.httpRequest.Method, rateLimitPath)
 	if err != nil {
-		slog.Warn("Fail to parse rate limits", "error", err)
+		return nil, nil, errors.Join(errAPIRequest, err)
 	}
 
-	if response.StatusCode == int(rateLimited) || response.StatusCode == int(timeout) {
-		request.retries++
-		if request.retries < request.maxRetries {
-			time.Sleep(time.Duration(request.retries) * time.Second)
+	failure := &RetryAttempt{
+		Attempt:    0,
+		Method:     request.httpRequest.Method,
+		Path:       rateLimitPath,
+		Idempotent: request.idempotent,
+		Response:   nil,
+		Err:        nil,
+		Waited:     0,
+	}
+
+	httpRequest, err := request.replay()
+	if err != nil {
+		return nil, nil, err
+	}
 
-			return request.Do()
+	//nolint:bodyclose // body is closed by the caller on success
+	response, err := request.api.client.Do(httpRequest)
+	if err != nil {
+		failure.Err = errors.Join(errAPIRequest, err)
+		if !request.idempotent {
+			failure.Err = errors.Join(errOutcomeUnknown, failure.Err)
 		}
+
+		return nil, failure, failure.Err
+	}
+
+	request.api.logger.Debug("Request status", "status", response.Status)
+
+	err = request.api.rateLimits.ParseRateLimits(request.httpRequest.Method, rateLimitPath, response)
+	if err != nil {
+		request.api.logger.Warn("Fail to parse rate limits", "error", err)
 	}
 
 	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		err := httpError(response.StatusCode, response.Status)
+		failure.Response = response
+		failure.Err = request.newAPIError(response, rateLimitPath)
+		request.closeBody(response.Body)
 
-		return nil, err
+		if !request.idempotent && ambiguousStatus(response.StatusCode) {
+			failure.Err = errors.Join(errOutcomeUnknown, failure.Err)
+		}
+
+		return nil, failure, failure.Err
 	}
 
-	data, err := io.ReadAll(response.Body)
+	return &responseBody{body: response.Body, request: request}, nil, nil
+}
+
+func (request *Request) closeBody(body io.Closer) {
+	err := body.Close()
 	if err != nil {
-		err := errors.Join(errReadingAPI, err)
+		request.api.logger.Warn("error closing api response body")
+	}
+}
 
-		return nil, err
+// responseBody is the body of a successful response, read as it is decoded.
+type responseBody struct {
+	body    io.ReadCloser
+	request *Request
+}
+
+// Read the body, failures to read it wrap errReadingAPI.
+func (body *responseBody) Read(data []byte) (int, error) {
+	n, err := body.body.Read(data)
+	if err == nil || errors.Is(err, io.EOF) {
+		return n, err //nolint:wrapcheck // io.EOF must be returned as is
+	}
+
+	err = errors.Join(errReadingAPI, err)
+	if !body.request.idempotent {
+		err = errors.Join(errOutcomeUnknown, err)
 	}
 
-	slog.Debug("Response body", "body", data)
+	return n, err
+}
 
-	return (*json.RawMessage)(&data), nil
+// Close the body and release the request.
+func (body *responseBody) Close() error {
+	body.request.closeBody(body.body)
+	body.request.cancel(nil)
+
+	return nil
+}
+
+// replay builds a copy of the http request with a fresh body for a new attempt,
+// as the body of a previous attempt is already consumed.
+func (request *Request) replay() (*http.Request, error) {
+	httpRequest := request.httpRequest.Clone(request.Ctx)
+	if request.httpRequest.GetBody == nil {
+		return httpRequest, nil
+	}
+
+	body, err := request.httpRequest.GetBody()
+	if err != nil {
+		return nil, errors.Join(errNewHTTP, errConversionBody, err)
+	}
+
+	httpRequest.Body = body
+
+	return httpRequest, nil
 }
 
 func (request *Request) http() *http.Request {
@@ -1,6 +1,7 @@
 package trading212
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -8,7 +9,11 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
 )
 
 func TestAPI_NewRequest(t *testing.T) {
@@ -186,3 +191,148 @@ func Test_httpError(t *testing.T) {
 		})
 	}
 }
+
+func Test_Request_Do_ReplayBody(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		operation func(api *API) error
+		attempts  int
+	}{
+		{
+			name: "Do should replay RequestReport body on every retry",
+			operation: func(api *API) error {
+				request := models.ReportRequest{}
+				request.DataIncluded.IncludeOrders = true
+				request.TimeFrom = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+				_, err := api.HistoricalEvents.RequestReport(request)
+
+				return err
+			},
+			attempts: 3,
+		},
+		{
+			name: "Do should replay CreatePie body on every retry",
+			operation: func(api *API) error {
+				_, err := api.Pies.CreatePie(models.PieRequest{PieMetaRequest: models.PieMetaRequest{Name: "foo"}})
+
+				return err
+			},
+			attempts: 3,
+		},
+		{
+			name: "Do should replay a non-replayable reader on every retry",
+			operation: func(api *API) error {
+				body := io.MultiReader(bytes.NewBufferString(`{"foo":`), bytes.NewBufferString(`"bar"}`))
+
+				request, err := api.NewRequest(http.MethodPost, RequestReport, body)
+				if err != nil {
+					return err
+				}
+
+				_, err = request.Do()
+
+				return err
+			},
+			attempts: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var (
+					mutex          sync.Mutex
+					bodies         []string
+					contentLengths []int64
+				)
+
+				mockAPI, terminate, err := newMockAPI(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							mutex.Lock()
+							defer mutex.Unlock()
+
+							data, _ := io.ReadAll(r.Body)
+							bodies = append(bodies, string(data))
+							contentLengths = append(contentLengths, r.ContentLength)
+
+							if len(bodies) < tt.attempts {
+								w.WriteHeader(http.StatusRequestTimeout)
+
+								return
+							}
+
+							_, _ = w.Write([]byte(`{}`))
+						},
+					),
+				)
+				defer terminate()
+
+				if err != nil {
+					t.Fatalf("Error creating mock api; %v", err)
+				}
+
+				policy := NewExponentialBackoff()
+				policy.BaseDelay = time.Millisecond
+				_ = WithRetryPolicy(policy)(mockAPI)
+
+				err = tt.operation(mockAPI)
+				if err != nil {
+					t.Fatalf("Error calling operation; %v", err)
+				}
+
+				if len(bodies) != tt.attempts {
+					t.Fatalf("expected %d attempts, got %d", tt.attempts, len(bodies))
+				}
+
+				for attempt, body := range bodies {
+					if body == "" || body != bodies[0] {
+						t.Errorf("attempt %d sent body %q, want %q", attempt+1, body, bodies[0])
+					}
+
+					if contentLengths[attempt] != int64(len(body)) {
+						t.Errorf(
+							"attempt %d sent Content-Length %d, want %d", attempt+1, contentLengths[attempt], len(body),
+						)
+					}
+				}
+			},
+		)
+	}
+}
+
+func Test_Request_Do_ReleasesContext(t *testing.T) {
+	t.Parallel()
+
+	mockAPI, terminate, err := newMockAPI(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{}`))
+			},
+		),
+	)
+	defer terminate()
+
+	if err != nil {
+		t.Fatalf("Error creating mock api; %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	request, err := mockAPI.NewRequestWithContext(ctx, http.MethodGet, GetAccountSummary, nil)
+	if err != nil {
+		t.Fatalf("NewRequestWithContext() error = %v", err)
+	}
+
+	_, err = request.Do()
+	if err != nil || request.(*Request).Ctx.Err() == nil {
+		t.Errorf("Do() error = %v, context error = %v, want the request context released",
+			err, request.(*Request).Ctx.Err())
+	}
+}
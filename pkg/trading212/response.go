@@ -1,10 +1,10 @@
 package trading212
 
 import (
-	"bytes"
-	"encoding/json"
 	"errors"
+	"io"
 	"iter"
+	"net/http"
 )
 
 var (
@@ -12,6 +12,7 @@ var (
 	errRequestEmpty     = errors.New("request is empty")
 	errEmptyIter        = errors.New("error, empty iterator")
 	errDecodingResponse = errors.New("error reading response json")
+	errResponseConsumed = errors.New("response already read, only the GET responses can be walked again")
 )
 
 // Response is a future abstraction that will normalize any API response.
@@ -19,13 +20,10 @@ var (
 type Response[T any] struct {
 	err     error
 	request IRequest
-	raw     *json.RawMessage
-}
-
-// paginatedResponse is a generic wrapper for paginated API responses.
-type paginatedResponse struct {
-	Items        *json.RawMessage `json:"items"`
-	NextPagePath *string          `json:"nextPagePath"`
+	// body of the first page, decoded as it is read by the first walk, nil once released.
+	body io.ReadCloser
+	// paginator fetches the next pages, and the first one again for the next walks.
+	paginator *Paginator[T]
 }
 
 func (r *Response[T]) validate() error {
@@ -37,7 +35,7 @@ func (r *Response[T]) validate() error {
 		return errRequestNil
 	}
 
-	if r.raw == nil {
+	if r.body == nil && r.paginator == nil {
 		return errRequestEmpty
 	}
 
@@ -46,108 +44,119 @@ func (r *Response[T]) validate() error {
 
 // Object get single response object.
 func (r *Response[T]) Object() (*T, error) {
-	iterator, err := r.Items()
-	if err != nil {
-		return nil, err
-	}
-
-	next, stop := iter.Pull(iterator)
-	defer stop()
-
-	value, ok := next()
-	if !ok {
-		return nil, errEmptyIter
+	for value, err := range r.All() {
+		return value, err
 	}
 
-	return value, nil
+	return nil, errEmptyIter
 }
 
 // Items get iterator over response results.
-//
-//nolint:cyclop,funlen  // TODO: too complex
+// Failures decoding the items or fetching the next pages silently end the iterator, use All to get them.
 func (r *Response[T]) Items() (iter.Seq[*T], error) {
 	err := r.validate()
 	if err != nil {
 		return nil, err
 	}
 
-	// detect paginated results
-	var paginatedResponse paginatedResponse
-	decoder := json.NewDecoder(bytes.NewBuffer(*r.raw)) //nolint:wsl,wsl_v5
-	decoder.DisallowUnknownFields()
-
-	err = decoder.Decode(&paginatedResponse)
-	if err != nil {
-		// assume data is array, but use like paginated
-		paginatedResponse.Items = r.raw
-		paginatedResponse.NextPagePath = nil
-	}
-
-	// decode current array
-	var data []*T
-	decoder = json.NewDecoder(bytes.NewBuffer(*paginatedResponse.Items)) //nolint:wsl,wsl_v5
-	decoder.DisallowUnknownFields()
-
-	err = decoder.Decode(&data)
-	if err != nil {
-		if len(data) > 0 {
-			return nil, errors.Join(errDecodingResponse, err)
+	iterator := func(yield func(*T) bool) {
+		for value, err := range r.All() {
+			if err != nil || !yield(value) {
+				return
+			}
 		}
+	}
 
-		var value *T
-		decoder = json.NewDecoder(bytes.NewBuffer(*paginatedResponse.Items)) //nolint:wsl,wsl_v5
-		decoder.DisallowUnknownFields()
+	return iterator, nil
+}
 
-		err = decoder.Decode(&value)
+// All get iterator over response results, across all pages, decoded as they are iterated.
+// Any failure, including while fetching a next page, is yielded as the last element.
+// The next walks of a GET response send its request again, so it can be iterated more than once.
+// The first walk releases the request, walk the Response to not hold on to its connection.
+func (r *Response[T]) All() iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		err := r.validate()
 		if err != nil {
-			return nil, errors.Join(errDecodingResponse, err)
+			yield(nil, err)
+
+			return
 		}
 
-		data = []*T{value}
-	}
+		if r.body == nil {
+			r.again(yield)
 
-	iterator := func(yield func(*T) bool) {
-		for _, value := range data {
-			if !yield(value) {
-				return
-			}
+			return
 		}
 
-		if paginatedResponse.NextPagePath == nil || *paginatedResponse.NextPagePath == "" {
-			return
+		options := decoding{mode: DecodingStrict, drifts: nil}
+		if r.paginator != nil {
+			options = r.paginator.decoding()
 		}
 
-		httpRequest := r.request.http()
-		query := httpRequest.URL.Query()
-		query.Set("cursor", *paginatedResponse.NextPagePath)
-		httpRequest.URL.RawQuery = query.Encode()
+		nextPagePath, stopped, err := decodeStream(r.body, options, func(value *T) bool {
+			return yield(value, nil)
+		})
 
-		data, err := r.request.Do()
-		if err != nil {
-			return
-		}
+		r.release()
 
-		response := &Response[T]{request: r.request, raw: data, err: nil}
+		switch {
+		case stopped:
+			return
+		case err != nil:
+			yield(nil, err)
 
-		nextIterator, err := response.Items()
-		if err != nil {
+			return
+		case nextPagePath == nil || *nextPagePath == "" || r.paginator == nil:
 			return
 		}
 
-		next, stop := iter.Pull(nextIterator)
-		defer stop()
+		paginator := *r.paginator
+		paginator.Resume(Cursor(*nextPagePath))
 
-		for {
-			value, ok := next()
-			if !ok {
+		for value, err := range paginator.All() {
+			if !yield(value, err) {
 				return
 			}
+		}
+	}
+}
 
-			if !yield(value) {
-				return
-			}
+// again walks the response from its first page, fetched again.
+func (r *Response[T]) again(yield func(*T, error) bool) {
+	if r.paginator == nil || r.paginator.method != http.MethodGet {
+		yield(nil, errResponseConsumed)
+
+		return
+	}
+
+	paginator := *r.paginator
+	paginator.Resume("")
+
+	for value, err := range paginator.All() {
+		if !yield(value, err) {
+			return
 		}
 	}
+}
 
-	return iterator, nil
+// release discards what the first walk left of the body, then closes it.
+func (r *Response[T]) release() {
+	if r.body == nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, r.body)
+	_ = r.body.Close()
+	r.body = nil
+}
+
+// discard releases a Response whose content is not needed, returning its error.
+func (r *Response[T]) discard() error {
+	if r.body != nil {
+		_ = r.body.Close()
+		r.body = nil
+	}
+
+	return r.err
 }
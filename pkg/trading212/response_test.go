@@ -1,9 +1,16 @@
 package trading212
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
 )
 
 func Test_Response_validate(t *testing.T) {
@@ -18,27 +25,30 @@ func Test_Response_validate(t *testing.T) {
 		{
 			name: "Response_validate should return inner error",
 			r: Response[any]{
-				err:     errors.New("mock error"),
-				request: nil,
-				raw:     nil,
+				err:       errors.New("mock error"),
+				request:   nil,
+				body:      nil,
+				paginator: nil,
 			},
 			wantErr: true,
 		},
 		{
 			name: "Response_validate should return error if no request",
 			r: Response[any]{
-				err:     nil,
-				request: nil,
-				raw:     &json.RawMessage{},
+				err:       nil,
+				request:   nil,
+				body:      io.NopCloser(strings.NewReader("")),
+				paginator: nil,
 			},
 			wantErr: true,
 		},
 		{
 			name: "Response_validate should return error if no data",
 			r: Response[any]{
-				err:     nil,
-				request: &Request{},
-				raw:     nil,
+				err:       nil,
+				request:   &Request{},
+				body:      nil,
+				paginator: nil,
 			},
 			wantErr: true,
 		},
@@ -55,3 +65,184 @@ func Test_Response_validate(t *testing.T) {
 		)
 	}
 }
+
+func Test_Response_All(t *testing.T) {
+	t.Parallel()
+
+	const firstPage = `{"items": [{"amount": 1, "currency": "EUR", "dateTime": "2019-08-24T14:15:22Z", ` +
+		`"reference": "a", "type": "DEPOSIT"}], "nextPagePath": "cursor-2"}`
+
+	tests := []struct {
+		name       string
+		secondPage string
+		status     int
+		wantItems  int
+		wantErr    bool
+	}{
+		{
+			name: "All should yield every page",
+			secondPage: `{"items": [{"amount": 2, "currency": "EUR", "dateTime": "2019-08-24T14:15:22Z", ` +
+				`"reference": "b", "type": "DEPOSIT"}], "nextPagePath": null}`,
+			status:    http.StatusOK,
+			wantItems: 2,
+			wantErr:   false,
+		},
+		{
+			name:       "All should yield a failing page",
+			secondPage: `{"message": "unavailable"}`,
+			status:     http.StatusNotFound,
+			wantItems:  1,
+			wantErr:    true,
+		},
+		{
+			name:       "All should yield an undecodable page",
+			secondPage: `{"items": [{"unexpected": true}], "nextPagePath": null}`,
+			status:     http.StatusOK,
+			wantItems:  1,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockAPI, terminate, err := newMockAPI(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Query().Get("cursor") == "" {
+							_, _ = fmt.Fprint(w, firstPage)
+
+							return
+						}
+
+						w.WriteHeader(tt.status)
+						_, _ = fmt.Fprint(w, tt.secondPage)
+					},
+				),
+			)
+			defer terminate()
+
+			if err != nil {
+				t.Fatalf("Error creating mock api; %v", err)
+			}
+
+			items, errs := 0, 0
+			transactions := mockAPI.HistoricalEvents.GetTransactionsIter(context.Background(), models.TransactionsQuery{})
+
+			for item, err := range transactions {
+				if err != nil {
+					errs++
+
+					continue
+				}
+
+				if item == nil {
+					t.Errorf("All() yielded a nil item")
+				}
+
+				items++
+			}
+
+			if items != tt.wantItems || (errs > 0) != tt.wantErr {
+				t.Errorf("All() yielded %d items and %d errors, want %d items, error %v",
+					items, errs, tt.wantItems, tt.wantErr)
+			}
+
+			iterator, err := mockAPI.HistoricalEvents.GetTransactions()
+			if err != nil {
+				t.Fatalf("Items() error = %v", err)
+			}
+
+			items = 0
+			for range iterator {
+				items++
+			}
+
+			if items != tt.wantItems {
+				t.Errorf("Items() yielded %d items, want %d", items, tt.wantItems)
+			}
+		})
+	}
+}
+
+func Test_Response_Streaming(t *testing.T) {
+	t.Parallel()
+
+	const item = `{"amount": 1, "currency": "EUR", "dateTime": "2019-08-24T14:15:22Z", ` +
+		`"reference": "a", "type": "DEPOSIT"}`
+
+	decoded := make(chan struct{})
+
+	mockAPI, terminate, err := newMockAPI(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprintf(w, `{"items": [%s`, item)
+				w.(http.Flusher).Flush() //nolint:forcetypeassert // httptest server
+
+				// the rest of the page is only sent once the first item is decoded
+				select {
+				case <-decoded:
+				case <-time.After(time.Second):
+				}
+
+				_, _ = fmt.Fprintf(w, `, %s], "nextPagePath": null}`, item)
+			},
+		),
+	)
+	defer terminate()
+
+	if err != nil {
+		t.Fatalf("Error creating mock api; %v", err)
+	}
+
+	response := runOperation[models.Transaction](context.Background(), mockAPI, http.MethodGet, GetTransactions, nil)
+
+	items, start := 0, time.Now()
+	for _, err := range response.All() {
+		if err != nil {
+			t.Fatalf("All() error = %v", err)
+		}
+
+		if items == 0 {
+			close(decoded)
+		}
+
+		items++
+	}
+
+	if items != 2 || time.Since(start) >= time.Second {
+		t.Errorf("All() yielded %d items in %s, want 2 items, the first before the page is complete",
+			items, time.Since(start))
+	}
+
+	// the next walk fetches the first page again
+	items = 0
+	for range response.All() {
+		items++
+	}
+
+	if items != 2 || response.body != nil {
+		t.Errorf("All() yielded %d items on the second walk, want 2 and the body released", items)
+	}
+}
+
+func Test_Response_Consumed(t *testing.T) {
+	t.Parallel()
+
+	mockAPI := newRoutesMockAPI(t, map[APIEndpoint]http.HandlerFunc{
+		http.MethodPost + " " + RequestReport: serveBody(`{"reportId": 42}`),
+	})
+
+	response := runOperation[models.ReportID](context.Background(), mockAPI, http.MethodPost, RequestReport, nil)
+
+	_, err := response.Object()
+	if err != nil {
+		t.Fatalf("Object() error = %v", err)
+	}
+
+	// sending the request again could request another report
+	_, err = response.Object()
+	if !errors.Is(err, errResponseConsumed) {
+		t.Errorf("Object() error = %v on the second walk, want %v", err, errResponseConsumed)
+	}
+}
@@ -0,0 +1,213 @@
+package trading212
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryBaseDelay    = 500 * time.Millisecond
+	defaultRetryMaxDelay     = 30 * time.Second
+	defaultRetryMaxTotalWait = 2 * time.Minute
+	defaultRetryJitter       = 0.2
+)
+
+// RetryAttempt describes a failed request attempt, as submitted to a RetryPolicy.
+type RetryAttempt struct {
+	// Attempt number of the failed attempt, starting at 1.
+	Attempt int
+	// Method of the http request.
+	Method string
+	// Path of the http request.
+	Path string
+	// Idempotent is false for requests, like order placements, that are only retried
+	// when rate-limited, whatever the policy says.
+	Idempotent bool
+	// Response received, nil on transport errors. Its body is already closed.
+	Response *http.Response
+	// Err of the attempt, either the transport error or the http error.
+	Err error
+	// Waited is the total time already spent waiting between previous attempts.
+	Waited time.Duration
+}
+
+// StatusCode of the attempt response, 0 on transport errors.
+func (attempt RetryAttempt) StatusCode() int {
+	if attempt.Response == nil {
+		return 0
+	}
+
+	return attempt.Response.StatusCode
+}
+
+// RetryPolicy decides whether a failed request attempt should be retried.
+type RetryPolicy interface {
+	// Retry reports whether the failed attempt should be retried, and the delay to wait before doing so.
+	Retry(attempt RetryAttempt) (time.Duration, bool)
+}
+
+// RetryHook is called before waiting for each retry.
+type RetryHook func(attempt RetryAttempt, delay time.Duration)
+
+// RetryRule lists what is worth retrying for an http method.
+type RetryRule struct {
+	// Statuses http codes to retry.
+	Statuses []int
+	// TransportErrors to retry, like connection reset or client timeout.
+	TransportErrors bool
+}
+
+// ExponentialBackoff is the default RetryPolicy, see NewExponentialBackoff.
+// The delay doubles on every attempt, starting from BaseDelay and capped to MaxDelay,
+// with a random Jitter ratio removed from it.
+// When the server tells when to come back, with the Retry-After header or the x-ratelimit-reset
+// header on a 429, that delay is used instead.
+type ExponentialBackoff struct {
+	// BaseDelay before the first retry.
+	BaseDelay time.Duration
+	// MaxDelay between two attempts.
+	MaxDelay time.Duration
+	// MaxTotalWait across all the retries of a request, a retry exceeding it is not attempted.
+	MaxTotalWait time.Duration
+	// Jitter ratio in [0, 1] of the delay that is randomly removed from it.
+	Jitter float64
+	// Rules per http method. Methods without a rule use DefaultRule.
+	Rules map[string]RetryRule
+	// DefaultRule for methods not in Rules.
+	DefaultRule RetryRule
+
+	now    func() time.Time
+	random func() float64
+}
+
+// NewExponentialBackoff creates the default ExponentialBackoff.
+// Idempotent methods are retried on timeouts, rate-limits, server errors and transport errors,
+// other methods only on timeouts and rate-limits.
+func NewExponentialBackoff() *ExponentialBackoff {
+	idempotent := RetryRule{
+		Statuses: []int{
+			http.StatusRequestTimeout,
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		TransportErrors: true,
+	}
+
+	return &ExponentialBackoff{
+		BaseDelay:    defaultRetryBaseDelay,
+		MaxDelay:     defaultRetryMaxDelay,
+		MaxTotalWait: defaultRetryMaxTotalWait,
+		Jitter:       defaultRetryJitter,
+		Rules: map[string]RetryRule{
+			http.MethodGet:     idempotent,
+			http.MethodHead:    idempotent,
+			http.MethodOptions: idempotent,
+			http.MethodPut:     idempotent,
+			http.MethodDelete:  idempotent,
+		},
+		DefaultRule: RetryRule{
+			Statuses:        []int{http.StatusRequestTimeout, http.StatusTooManyRequests},
+			TransportErrors: false,
+		},
+		now:    time.Now,
+		random: rand.Float64, //nolint:gosec // jitter does not need a secure random
+	}
+}
+
+// Retry implements RetryPolicy.
+func (policy *ExponentialBackoff) Retry(attempt RetryAttempt) (time.Duration, bool) {
+	if !policy.retryable(attempt) {
+		return 0, false
+	}
+
+	delay, found := policy.serverDelay(attempt.Response)
+	if !found {
+		delay = policy.backoff(attempt.Attempt)
+	}
+
+	if policy.MaxTotalWait > 0 && attempt.Waited+delay > policy.MaxTotalWait {
+		return 0, false
+	}
+
+	return delay, true
+}
+
+func (policy *ExponentialBackoff) retryable(attempt RetryAttempt) bool {
+	rule, found := policy.Rules[attempt.Method]
+	if !found {
+		rule = policy.DefaultRule
+	}
+
+	if attempt.Response == nil {
+		return rule.TransportErrors && attempt.Err != nil
+	}
+
+	return slices.Contains(rule.Statuses, attempt.Response.StatusCode)
+}
+
+// serverDelay reads the delay requested by the server, if any.
+func (policy *ExponentialBackoff) serverDelay(response *http.Response) (time.Duration, bool) {
+	if response == nil {
+		return 0, false
+	}
+
+	now := time.Now()
+	if policy.now != nil {
+		now = policy.now()
+	}
+
+	retryAfter := response.Header.Get("Retry-After")
+	if retryAfter != "" {
+		seconds, err := strconv.ParseUint(retryAfter, 10, 32)
+		if err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+
+		date, err := http.ParseTime(retryAfter)
+		if err == nil {
+			return max(date.Sub(now), 0), true
+		}
+	}
+
+	if response.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	reset, err := strconv.ParseInt(response.Header.Get(RateLimitHeaderReset), 10, 64)
+	if err != nil || reset <= 0 {
+		return 0, false
+	}
+
+	return max(time.Unix(reset, 0).Sub(now), 0), true
+}
+
+func (policy *ExponentialBackoff) backoff(attempt int) time.Duration {
+	delay := policy.BaseDelay
+
+	for range max(attempt-1, 0) {
+		if policy.MaxDelay > 0 && delay >= policy.MaxDelay {
+			break
+		}
+
+		delay *= 2
+	}
+
+	if policy.MaxDelay > 0 {
+		delay = min(delay, policy.MaxDelay)
+	}
+
+	random := rand.Float64 //nolint:gosec // jitter does not need a secure random
+	if policy.random != nil {
+		random = policy.random
+	}
+
+	jitter := min(max(policy.Jitter, 0), 1)
+
+	return delay - time.Duration(jitter*random()*float64(delay))
+}
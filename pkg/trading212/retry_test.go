@@ -0,0 +1,268 @@
+package trading212
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyrbil/go-trading212/pkg/trading212/models"
+)
+
+func Test_ExponentialBackoff_Retry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+
+	newResponse := func(code int, headers map[string]string) *http.Response {
+		response := &http.Response{StatusCode: code, Header: http.Header{}}
+		for header, value := range headers {
+			response.Header.Set(header, value)
+		}
+
+		return response
+	}
+
+	tests := []struct {
+		name      string
+		attempt   RetryAttempt
+		wantDelay time.Duration
+		wantRetry bool
+	}{
+		{
+			name:      "Retry should back-off exponentially",
+			attempt:   RetryAttempt{Attempt: 3, Method: http.MethodGet, Response: newResponse(503, nil)},
+			wantDelay: 4 * time.Second,
+			wantRetry: true,
+		},
+		{
+			name:      "Retry should cap the delay",
+			attempt:   RetryAttempt{Attempt: 30, Method: http.MethodGet, Response: newResponse(503, nil)},
+			wantDelay: 10 * time.Second,
+			wantRetry: true,
+		},
+		{
+			name: "Retry should use Retry-After seconds",
+			attempt: RetryAttempt{
+				Attempt: 1, Method: http.MethodGet, Response: newResponse(503, map[string]string{"Retry-After": "7"}),
+			},
+			wantDelay: 7 * time.Second,
+			wantRetry: true,
+		},
+		{
+			name: "Retry should use Retry-After date",
+			attempt: RetryAttempt{
+				Attempt: 1, Method: http.MethodGet, Response: newResponse(503, map[string]string{
+					"Retry-After": now.Add(3 * time.Second).UTC().Format(http.TimeFormat),
+				}),
+			},
+			wantDelay: 3 * time.Second,
+			wantRetry: true,
+		},
+		{
+			name: "Retry should wait for the rate-limit reset",
+			attempt: RetryAttempt{
+				Attempt: 1, Method: http.MethodPost, Response: newResponse(429, map[string]string{
+					RateLimitHeaderReset: strconv.FormatInt(now.Add(5*time.Second).Unix(), 10),
+				}),
+			},
+			wantDelay: 5 * time.Second,
+			wantRetry: true,
+		},
+		{
+			name:      "Retry should retry transport errors on idempotent methods",
+			attempt:   RetryAttempt{Attempt: 1, Method: http.MethodGet, Err: errors.New("mock error")},
+			wantDelay: time.Second,
+			wantRetry: true,
+		},
+		{
+			name:      "Retry should not retry transport errors on other methods",
+			attempt:   RetryAttempt{Attempt: 1, Method: http.MethodPost, Err: errors.New("mock error")},
+			wantRetry: false,
+		},
+		{
+			name:      "Retry should not retry server errors on other methods",
+			attempt:   RetryAttempt{Attempt: 1, Method: http.MethodPost, Response: newResponse(503, nil)},
+			wantRetry: false,
+		},
+		{
+			name:      "Retry should not retry client errors",
+			attempt:   RetryAttempt{Attempt: 1, Method: http.MethodGet, Response: newResponse(400, nil)},
+			wantRetry: false,
+		},
+		{
+			name: "Retry should respect the max total wait",
+			attempt: RetryAttempt{
+				Attempt: 2, Method: http.MethodGet, Response: newResponse(503, nil), Waited: 59 * time.Second,
+			},
+			wantRetry: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				policy := NewExponentialBackoff()
+				policy.BaseDelay = time.Second
+				policy.MaxDelay = 10 * time.Second
+				policy.MaxTotalWait = time.Minute
+				policy.now = func() time.Time { return now }
+				policy.random = func() float64 { return 0 }
+
+				delay, retry := policy.Retry(tt.attempt)
+				if retry != tt.wantRetry {
+					t.Fatalf("Retry() retry = %v, want %v", retry, tt.wantRetry)
+				}
+
+				if retry && delay != tt.wantDelay {
+					t.Errorf("Retry() delay = %v, want %v", delay, tt.wantDelay)
+				}
+			},
+		)
+	}
+}
+
+func Test_ExponentialBackoff_Jitter(t *testing.T) {
+	t.Parallel()
+
+	policy := &ExponentialBackoff{BaseDelay: time.Second, Jitter: 0.5, random: func() float64 { return 1 }}
+
+	if delay := policy.backoff(1); delay != 500*time.Millisecond {
+		t.Errorf("backoff() delay = %v, want %v", delay, 500*time.Millisecond)
+	}
+}
+
+func Test_Request_Do_Retry(t *testing.T) {
+	t.Parallel()
+
+	fastPolicy := NewExponentialBackoff()
+	fastPolicy.BaseDelay = time.Millisecond
+
+	t.Run(
+		"Do should retry server errors and call the hook", func(t *testing.T) {
+			t.Parallel()
+
+			var mutex sync.Mutex
+
+			calls := 0
+			mockAPI, terminate, err := newMockAPI(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, _ *http.Request) {
+						mutex.Lock()
+						defer mutex.Unlock()
+
+						calls++
+						if calls < 3 {
+							w.WriteHeader(http.StatusServiceUnavailable)
+
+							return
+						}
+
+						_, _ = w.Write([]byte(`[]`))
+					},
+				),
+			)
+			defer terminate()
+
+			if err != nil {
+				t.Fatalf("Error creating mock api; %v", err)
+			}
+
+			var attempts []int
+
+			_ = WithRetryPolicy(fastPolicy)(mockAPI)
+			_ = WithRetryHook(func(attempt RetryAttempt, _ time.Duration) {
+				attempts = append(attempts, attempt.Attempt)
+			})(mockAPI)
+
+			_, err = mockAPI.Positions.GetAllPositionsWithContext(context.Background())
+			if err != nil {
+				t.Fatalf("Error calling operation; %v", err)
+			}
+
+			if calls != 3 || len(attempts) != 2 || attempts[1] != 2 {
+				t.Errorf("expected 3 calls and 2 retries, got %d calls and retries %v", calls, attempts)
+			}
+		},
+	)
+
+	t.Run(
+		"Do should not retry server errors on POST", func(t *testing.T) {
+			t.Parallel()
+
+			var mutex sync.Mutex
+
+			calls := 0
+			mockAPI, terminate, err := newMockAPI(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, _ *http.Request) {
+						mutex.Lock()
+						defer mutex.Unlock()
+
+						calls++
+						w.WriteHeader(http.StatusServiceUnavailable)
+					},
+				),
+			)
+			defer terminate()
+
+			if err != nil {
+				t.Fatalf("Error creating mock api; %v", err)
+			}
+
+			_ = WithRetryPolicy(fastPolicy)(mockAPI)
+
+			_, err = mockAPI.Pies.DuplicatePiesWithContext(context.Background(), 0, models.PieMetaRequest{})
+			if !errors.Is(err, errNon200) {
+				t.Errorf("expected %v, got %v", errNon200, err)
+			}
+
+			if calls != 1 {
+				t.Errorf("expected a single call, got %d", calls)
+			}
+		},
+	)
+
+	t.Run(
+		"Do should stop at max retries", func(t *testing.T) {
+			t.Parallel()
+
+			var mutex sync.Mutex
+
+			calls := 0
+			mockAPI, terminate, err := newMockAPI(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, _ *http.Request) {
+						mutex.Lock()
+						defer mutex.Unlock()
+
+						calls++
+						w.WriteHeader(http.StatusTooManyRequests)
+					},
+				),
+			)
+			defer terminate()
+
+			if err != nil {
+				t.Fatalf("Error creating mock api; %v", err)
+			}
+
+			_ = WithRetryPolicy(fastPolicy)(mockAPI)
+			_ = WithMaxRetries(3)(mockAPI)
+
+			_, err = mockAPI.Positions.GetAllPositionsWithContext(context.Background())
+			if !errors.Is(err, errHTTP429) {
+				t.Errorf("expected %v, got %v", errHTTP429, err)
+			}
+
+			if calls != 4 {
+				t.Errorf("expected 4 calls, got %d", calls)
+			}
+		},
+	)
+}
@@ -2,9 +2,12 @@ package trading212
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
+	"strings"
+	"time"
 )
 
 // SecureString is a string type that doesn't print.
@@ -23,34 +26,47 @@ func (s SecureString) MarshalJSON() ([]byte, error) {
 
 var errConversionBody = errors.New("error converting request body")
 
-// helper struct to have a json reader object.
-type jsonBody struct {
-	data       any
-	marshaller func(any) ([]byte, error)
-	reader     func([]byte, []byte) (int, error)
+// newJSONBody marshals the data once into a replayable request body.
+// http.NewRequest recognizes a *bytes.Reader, and sets the request GetBody and ContentLength from it,
+// so every retry or redirect sends the exact same payload.
+func newJSONBody(data any) (*bytes.Reader, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Join(errConversionBody, err)
+	}
+
+	return bytes.NewReader(jsonData), nil
 }
 
-func newJSONBody(data any) *jsonBody {
-	return &jsonBody{
-		data:       data,
-		marshaller: json.Marshal,
-		reader: func(data []byte, buf []byte) (int, error) {
-			return bytes.NewReader(data).Read(buf)
-		},
+// replayableBody buffers a request body that http.NewRequest would not be able to replay.
+func replayableBody(body io.Reader) (io.Reader, error) {
+	switch body.(type) {
+	case nil, *bytes.Reader, *bytes.Buffer, *strings.Reader:
+		return body, nil
+	default:
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, errors.Join(errConversionBody, err)
+		}
+
+		return bytes.NewReader(data), nil
 	}
 }
 
-// Read as json.
-func (b jsonBody) Read(buf []byte) (int, error) {
-	jsonData, err := b.marshaller(b.data)
-	if err != nil {
-		return 0, errors.Join(errConversionBody, err)
+// sleepContext pauses the current goroutine for at least the given duration,
+// or until the context is done, in which case the context cause is returned.
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return context.Cause(ctx)
 	}
 
-	read, err := b.reader(jsonData, buf)
-	if err != nil && !errors.Is(err, io.EOF) {
-		return read, errors.Join(errConversionBody, err)
-	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
 
-	return read, err
+	select {
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case <-timer.C:
+		return nil
+	}
 }
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	models "github.com/cyrbil/go-trading212/pkg/trading212/models"
+	"io"
 	"reflect"
 	"testing"
+	"testing/iotest"
+
+	models "github.com/cyrbil/go-trading212/pkg/trading212/models"
 )
 
 func Test_SecureString(t *testing.T) {
@@ -34,67 +37,28 @@ func Test_SecureString(t *testing.T) {
 	}
 }
 
-func Test_jsonBody_Read(t *testing.T) {
+func Test_newJSONBody(t *testing.T) {
 	t.Parallel()
 
-	type args struct {
-		buf []byte
-	}
 	tests := []struct {
-		name     string
-		jsonBody *jsonBody
-		args     args
-		want     string
-		wantErr  error
+		name    string
+		data    any
+		want    string
+		wantErr error
 	}{
 		{
-			name: "jsonBody.Read() validations",
-			jsonBody: newJSONBody(
-				&models.PieMetaRequest{
-					Icon: "foo",
-					Name: "bar",
-				},
-			),
-			args: args{
-				buf: make([]byte, 30),
+			name: "newJSONBody() validations",
+			data: &models.PieMetaRequest{
+				Icon: "foo",
+				Name: "bar",
 			},
 			want:    `{"icon":"foo","name":"bar"}`,
 			wantErr: nil,
 		},
 		{
-			name: "jsonBody.Read() validations with json marshalling error",
-			jsonBody: &jsonBody{
-				data: &models.PieMetaRequest{
-					Icon: "foo",
-					Name: "bar",
-				},
-				marshaller: func(_ any) ([]byte, error) {
-					return nil, errors.New("mock error")
-				},
-				reader: nil,
-			},
-			args: args{
-				buf: make([]byte, 30),
-			},
-			want:    `{"icon":"foo","name":"bar"}`,
-			wantErr: errConversionBody,
-		},
-		{
-			name: "jsonBody.Read() validations with json reading error",
-			jsonBody: &jsonBody{
-				data: &models.PieMetaRequest{
-					Icon: "foo",
-					Name: "bar",
-				},
-				marshaller: json.Marshal,
-				reader: func(_ []byte, _ []byte) (int, error) {
-					return 0, errors.New("mock error")
-				},
-			},
-			args: args{
-				buf: make([]byte, 30),
-			},
-			want:    `{"icon":"foo","name":"bar"}`,
+			name:    "newJSONBody() validations with json marshalling error",
+			data:    make(chan int),
+			want:    "",
 			wantErr: errConversionBody,
 		},
 	}
@@ -103,22 +67,54 @@ func Test_jsonBody_Read(t *testing.T) {
 			tt.name, func(t *testing.T) {
 				t.Parallel()
 
-				got, err := tt.jsonBody.Read(tt.args.buf)
-				if err != nil {
+				body, err := newJSONBody(tt.data)
+				if err != nil || tt.wantErr != nil {
 					if !errors.Is(err, tt.wantErr) {
-						t.Errorf("Read() error = %v, wantErr %v", err, tt.wantErr)
+						t.Errorf("newJSONBody() error = %v, wantErr %v", err, tt.wantErr)
 					}
+
 					return
 				}
 
-				if got < len(tt.want) {
-					t.Errorf("jsonBody.Read() length error; got = %v, want %v", got, len(tt.want))
-				}
+				// read twice, with a small buffer, the reader must reach EOF
+				for range 2 {
+					_, _ = body.Seek(0, io.SeekStart)
 
-				if !bytes.HasPrefix(tt.args.buf, []byte(tt.want)) {
-					t.Errorf("jsonBody.Read() data error; got = %s, want %v", tt.args.buf, tt.want)
+					got, err := io.ReadAll(iotest.OneByteReader(body))
+					if err != nil {
+						t.Fatalf("newJSONBody() read error = %v", err)
+					}
+
+					if string(got) != tt.want {
+						t.Errorf("newJSONBody() data error; got = %s, want %v", got, tt.want)
+					}
 				}
 			},
 		)
 	}
 }
+
+func Test_replayableBody(t *testing.T) {
+	t.Parallel()
+
+	replayable := bytes.NewReader([]byte("foo"))
+
+	body, err := replayableBody(replayable)
+	if err != nil || body != replayable {
+		t.Errorf("replayableBody() should keep replayable readers; got = %v, err = %v", body, err)
+	}
+
+	body, err = replayableBody(io.MultiReader(bytes.NewBufferString("foo"), bytes.NewBufferString("bar")))
+	if err != nil {
+		t.Fatalf("replayableBody() error = %v", err)
+	}
+
+	if _, ok := body.(*bytes.Reader); !ok {
+		t.Errorf("replayableBody() should buffer other readers; got = %T", body)
+	}
+
+	_, err = replayableBody(iotest.ErrReader(errors.New("mock error")))
+	if !errors.Is(err, errConversionBody) {
+		t.Errorf("replayableBody() error = %v, wantErr %v", err, errConversionBody)
+	}
+}